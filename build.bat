@@ -1,3 +1,5 @@
+cd schema
 peg erdm.peg
-go-bindata -o=templates_files.go ./templates/...
+powershell -NoProfile -Command "(Get-Content erdm.peg.go) -replace '^func Pretty\(', 'func pretty(' -replace '^func Size\(', 'func size(' | Set-Content erdm.peg.go"
+cd ..
 go build
\ No newline at end of file
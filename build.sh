@@ -1,7 +1,5 @@
 #!/bin/sh
 
-rm erdm.peg.go
-peg erdm.peg
-rm templates_files.go
-go-bindata -o=templates_files.go ./templates/...
+rm schema/erdm.peg.go
+(cd schema && peg erdm.peg && sed -i.orig -e 's/^func Pretty(/func pretty(/' -e 's/^func Size(/func size(/' erdm.peg.go && rm erdm.peg.go.orig)
 gox -osarch "linux/amd64 darwin/amd64 windows/amd64 windows/i386" -output "bin/{{.Dir}}_{{.OS}}_{{.Arch}}"
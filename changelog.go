@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/unok/erdm/schema"
+)
+
+// changelog: 2 つの .erdm の差分を HTML のページにする。
+
+func changelogMain(args []string) int {
+	usage := "Usage: erdm changelog [-o changelog.html] [-doc erd.html] old.erdm new.erdm"
+	fs := flag.NewFlagSet("changelog", flag.ExitOnError)
+	output_file := fs.String("o", "", "output file (default: standard output)")
+	doc := fs.String("doc", "", "HTML document to link the tables to (default: the html output of new.erdm)")
+	files := parseInterspersed(fs, args)
+	if len(files) != 2 {
+		fmt.Println(usage)
+		return 1
+	}
+	o, err := loadErdM(files[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	n, err := loadErdM(files[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	c := &schema.Changelog{Title: n.Title, OldName: files[0], NewName: files[1], DocFilename: *doc, Diff: schema.Diff(o, n)}
+	if len(c.DocFilename) == 0 {
+		f := filepath.Base(files[1])
+		c.DocFilename = f[:len(f)-len(path.Ext(f))] + ".html"
+	}
+	if len(*output_file) == 0 {
+		err = c.Render(os.Stdout)
+	} else {
+		err = writeFile(*output_file, c.Render)
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/unok/erdm/schema"
+)
+
+// parseInterspersed は "old.erdm new.erdm -dialect=pg" のようにフラグが引数の後にあっても解釈する。
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	rest := []string{}
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return rest
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+}
+
+func diffMain(args []string) int {
+	usage := "Usage: erdm diff [-dialect pg|mysql] [-o up.sql] [-down down.sql] old.erdm new.erdm"
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	dialect := fs.String("dialect", "pg", "SQL dialect of the migration ("+strings.Join(schema.MigrationDialects(), ",")+")")
+	output_file := fs.String("o", "", "output file of the forward migration (default: standard output)")
+	down_file := fs.String("down", "", "output file of the reverse (down) migration")
+	files := parseInterspersed(fs, args)
+	if len(files) != 2 {
+		fmt.Println(usage)
+		return 1
+	}
+	if !slices.Contains(schema.MigrationDialects(), *dialect) {
+		fmt.Printf("unknown dialect: %s (available: %s)\n", *dialect, strings.Join(schema.MigrationDialects(), ","))
+		return 1
+	}
+	o, err := loadErdM(files[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	n, err := loadErdM(files[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	up := schema.Diff(o, n)
+	if len(*output_file) == 0 {
+		err = up.Migration(*dialect, os.Stdout)
+	} else {
+		err = writeFile(*output_file, func(w io.Writer) error {
+			return up.Migration(*dialect, w)
+		})
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if len(*down_file) > 0 {
+		down := schema.Diff(n, o)
+		err = writeFile(*down_file, func(w io.Writer) error {
+			return down.Migration(*dialect, w)
+		})
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+	}
+	return 0
+}
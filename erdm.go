@@ -5,60 +5,16 @@ import (
 	"os"
 	"log"
 	"io/ioutil"
-	"reflect"
 	htmltemplate "html/template"
-	"text/template"
+	"io"
 	"os/exec"
 	"strings"
 	"flag"
 	"path/filepath"
 	"path"
-)
-
-type TableRelation struct {
-	TableNameReal          string
-	CardinalitySource      string
-	CardinalityDestination string
-}
-
-type Index struct {
-	Title    string
-	Columns  []string
-	IsUnique bool
-}
-
-type Column struct {
-	TitleReal    string
-	Title        string
-	Type         string
-	AllowNull    bool
-	IsUnique     bool
-	IsPrimaryKey bool
-	IsForeignKey bool
-	Default      string
-	Relation     TableRelation
-	Comments     []string
-	IndexIndexes []int
-	WithoutErd   bool
-}
-
-type Table struct {
-	TitleReal       string
-	Title           string
-	Columns         []Column
-	CurrentColumnId int
-	PrimaryKeys     []int
-	Indexes         []Index
-	CurrentIndexId  int
-}
 
-type ErdM struct {
-	Title          string
-	Tables         []Table
-	CurrentTableId int
-	ImageFilename  string
-	IsError        bool
-}
+	"github.com/unok/erdm/schema"
+)
 
 func openFile(filename string) *os.File {
 	fp, err := os.OpenFile(filename, os.O_RDONLY, 0644)
@@ -76,360 +32,221 @@ func readAll(fp *os.File) []byte {
 	return bs
 }
 
-func (e *ErdM) setTitle(t string) {
-	e.Title = t
-}
-
-func (e *ErdM) addTableTitleReal(t string) {
-	e.Tables = append(e.Tables, Table{TitleReal: t})
-	e.CurrentTableId = len(e.Tables) - 1
-}
-
-func (e *ErdM) addTableTitle(t string) {
-	t = strings.Trim(t, "\"")
-	e.Tables[e.CurrentTableId].Title = t
-}
-
-func (e *ErdM) addPrimaryKey(text string) {
-	if (len(text) > 0) {
-		if (in_array(0, e.Tables[e.CurrentTableId].PrimaryKeys)) {
-			e.Tables[e.CurrentTableId].PrimaryKeys = append(e.Tables[e.CurrentTableId].PrimaryKeys, 1)
-		} else {
-			e.Tables[e.CurrentTableId].PrimaryKeys = append(e.Tables[e.CurrentTableId].PrimaryKeys, 0)
-		}
-	} else {
-		e.Tables[e.CurrentTableId].PrimaryKeys = append(e.Tables[e.CurrentTableId].PrimaryKeys, e.Tables[e.CurrentTableId].CurrentColumnId + 1)
-	}
-}
-
-func (e *ErdM) setColumnNameReal(t string) {
-	e.Tables[e.CurrentTableId].Columns = append(e.Tables[e.CurrentTableId].Columns, Column{TitleReal: t, AllowNull: true, IsUnique: false, IsForeignKey: false, WithoutErd: false})
-	e.Tables[e.CurrentTableId].CurrentColumnId = len(e.Tables[e.CurrentTableId].Columns) - 1
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].IsPrimaryKey = e.Tables[e.CurrentTableId].isPrimaryKey(e.Tables[e.CurrentTableId].CurrentColumnId)
-}
-
-func (e *ErdM) setColumnName(t string) {
-	t = strings.Trim(t, "\"")
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Title = t
-}
-
-func (e *ErdM) addColumnType(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Type = t
-}
-
-func (e *ErdM) setNotNull() {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].AllowNull = false
-}
-func (e *ErdM) setUnique() {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].IsUnique = true
-}
-
-func (e *ErdM) setColumnDefault(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Default = t
-}
-func (e *ErdM) setWithoutErd() {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].WithoutErd = true
-}
-func (e *ErdM) setRelationSource(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Relation.CardinalitySource = t
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].IsForeignKey = true
-}
-
-func (e *ErdM) setRelationDestination(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Relation.CardinalityDestination = t
-}
-
-func (e *ErdM) setRelationTableNameReal(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Relation.TableNameReal = t
-}
-
-func (e *ErdM) addComment(t string) {
-	e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Comments = append(e.Tables[e.CurrentTableId].Columns[e.Tables[e.CurrentTableId].CurrentColumnId].Comments, t)
-}
-
-func (e *ErdM) setIndexName(t string) {
-	e.Tables[e.CurrentTableId].Indexes = append(e.Tables[e.CurrentTableId].Indexes, Index{Title: t, IsUnique: false})
-	e.Tables[e.CurrentTableId].CurrentIndexId = len(e.Tables[e.CurrentTableId].Indexes) - 1
-}
-
-func (e *ErdM) setUniqueIndex() {
-	e.Tables[e.CurrentTableId].Indexes[e.Tables[e.CurrentTableId].CurrentIndexId].IsUnique = true
-}
-
-func (e *ErdM) setIndexColumn(t string) {
-	e.Tables[e.CurrentTableId].Indexes[e.Tables[e.CurrentTableId].CurrentIndexId].Columns = append(e.Tables[e.CurrentTableId].Indexes[e.Tables[e.CurrentTableId].CurrentIndexId].Columns, t)
-	i, err := e.Tables[e.CurrentTableId].getColumnIndex(t)
+// readSVG は dot が出力した SVG から XML 宣言と DOCTYPE を除き、HTML に埋め込める形で返す。
+func readSVG(filename string) (string, error) {
+	bs, err := ioutil.ReadFile(filename)
 	if err != nil {
-		fmt.Println(err)
+		return "", err
 	}
-	e.Tables[e.CurrentTableId].Columns[i].IndexIndexes = append(e.Tables[e.CurrentTableId].Columns[i].IndexIndexes, e.Tables[e.CurrentTableId].CurrentIndexId)
-}
-
-func (t *Table) getColumnIndex(s string) (int, error) {
-	for i, v := range t.Columns {
-		if v.TitleReal == s {
-			return i, nil
-		}
+	s := string(bs)
+	if i := strings.Index(s, "<svg"); i >= 0 {
+		s = s[i:]
 	}
-	return -1, os.ErrInvalid
+	return s, nil
 }
 
-func in_array(val interface{}, array interface{}) (exists bool) {
-	exists = false
-
-	switch reflect.TypeOf(array).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(array)
-
-		for i := 0; i < s.Len(); i++ {
-			if reflect.DeepEqual(val, s.Index(i).Interface()) == true {
-				exists = true
-				return
-			}
-		}
+// loadErdM は .erdm（拡張子が .dbml なら DBML）を読み込み、外部キーまで組み立てる。
+// 警告は標準エラーに表示する。
+func loadErdM(filename string) (*schema.Schema, error) {
+	fp := openFile(filename)
+	defer fp.Close()
+	erd, warnings, err := parseErdM(filename, fp)
+	if err != nil {
+		return nil, err
 	}
-
-	return
-}
-
-func (t *Table) isPrimaryKey(index int) bool {
-	return in_array(index, t.PrimaryKeys)
-}
-
-func (c *Column) HasDefaultSetting() bool {
-	return len(c.Default) > 0
-}
-
-func (c *Column) HasRelation() bool {
-	return len(c.Relation.TableNameReal) > 0
-}
-
-func (c *Column) HasComment() bool {
-	return len(c.Comments) > 0
-}
-
-func (t *Table) GetPrimaryKeyColumns() string {
-	ps := []string{}
-	for _, pk := range t.PrimaryKeys {
-		ps = append(ps, t.Columns[pk].TitleReal)
+	for _, d := range warnings {
+		fmt.Fprintln(os.Stderr, d)
 	}
-	return strings.Join(ps, ", ");
+	return erd, nil
 }
 
-func (i *Index) GetIndexColumns() string {
-	return strings.Join(i.Columns, ", ");
-}
-
-func (c *ErdM) Err(pos int, buffer string) {
-	fmt.Println("")
-	a := strings.Split(buffer[:pos], "\n")
-	row := len(a) - 1
-	column := len(a[row]) - 1
-
-	lines := strings.Split(buffer, "\n")
-	for i := row - 5; i <= row; i++ {
-		if i < 0 {
-			i = 0
-		}
-
-		fmt.Println(lines[i])
-	}
-
-	s := ""
-	for i := 0; i <= column; i++ {
-		s += " "
+// parseErdM は filename の拡張子に応じて r を .erdm か DBML として読む。
+// エラーがあれば Diagnostics を返す。エラーがなければ警告を返す。
+func parseErdM(filename string, r io.Reader) (*schema.Schema, schema.Diagnostics, error) {
+	parse := schema.Parse
+	if strings.ToLower(path.Ext(filename)) == ".dbml" {
+		parse = schema.ParseDBML
 	}
-	ln := len(strings.Trim(lines[row], " \r\n"))
-	for i := column + 1; i < ln; i++ {
-		s += "~"
+	erd, ds := parse(r)
+	diagnostics := schema.Diagnostics(ds)
+	diagnostics.SetFile(filename)
+	if diagnostics.HasError() {
+		return nil, nil, diagnostics
 	}
-	fmt.Println(s)
-
-	fmt.Println("error")
-	c.IsError = true
+	return erd, diagnostics, nil
 }
 
 func main() {
-	// check dot command
-	dot_err := exec.Command("dot", "-?").Run()
-	if dot_err != nil {
-		fmt.Println(dot_err)
-		fmt.Println("Please check a graphviz(dot) setting.")
-		return
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import-sql":
+			os.Exit(importSQLMain(os.Args[2:]))
+		case "import-sqlite":
+			os.Exit(importSQLiteMain(os.Args[2:]))
+		case "diff":
+			os.Exit(diffMain(os.Args[2:]))
+		case "review":
+			os.Exit(reviewMain(os.Args[2:]))
+		case "changelog":
+			os.Exit(changelogMain(os.Args[2:]))
+		case "lint":
+			os.Exit(lintMain(os.Args[2:]))
+		case "lsp":
+			os.Exit(lspMain(os.Args[2:]))
+		case "fmt":
+			os.Exit(fmtMain(os.Args[2:]))
+		}
 	}
+	os.Exit(generateMain())
+}
 
-	usage := "Usage: erdm [-output_dir directory_name] erd.erdm"
+// generateMain は .erdm から各形式のファイルを生成する。終了コードを返す。
+func generateMain() int {
+	usage := "Usage: erdm [-output_dir directory_name] [-formats pg,sqlite3,html,...] [-strict_types] erd.erdm|erd.dbml"
 
 	// check arguments
 	wd, _ := os.Getwd()
 	output_dir := flag.String("output_dir", wd, "output directory")
+	formats_string := flag.String("formats", strings.Join(defaultFormats, ","), "comma separated output formats ("+strings.Join(getFormatNames(), ",")+")")
+	strict_types := flag.Bool("strict_types", false, "fail on unknown column types instead of writing them verbatim")
 	flag.Parse()
 	if len(flag.Args()) == 0 {
 		fmt.Println(usage)
-		return
+		return 1
+	}
+	formats, err := parseFormats(*formats_string)
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println(usage)
+		return 1
+	}
+
+	// check dot command
+	if needsGraphviz(formats) {
+		dot_err := checkGraphviz()
+		if dot_err != nil {
+			fmt.Println(dot_err)
+			fmt.Println("Please check a graphviz(dot) setting.")
+			return 1
+		}
 	}
+
 	input_file := flag.Args()[0]
-	stat, err := os.Stat(input_file)
+	input_stat, err := os.Stat(input_file)
 	if err != nil {
 		fmt.Println(err)
 		fmt.Println(usage)
-		return
+		return 1
 	}
-	if stat.IsDir() == true {
+	if input_stat.IsDir() == true {
 		fmt.Println("Please set inputfile: " + input_file)
 		fmt.Println(usage)
-		return
+		return 1
 	}
 
-	stat, err = os.Stat(*output_dir)
+	stat, err := os.Stat(*output_dir)
 	if err != nil {
 		fmt.Println(err)
 		fmt.Println(usage)
-		return
+		return 1
 	}
 	if stat.IsDir() != true {
 		fmt.Println("Please set output_dir: " + *output_dir)
 		fmt.Println(usage)
-		return
+		return 1
 	}
 
 	f := filepath.Base(input_file)
 	basename := f[:len(f) - len(path.Ext(f))]
 
-	fp := openFile(input_file)
-	content := readAll(fp)
-	parser := &Parser{Buffer: string(content)}
-	parser.Init()
-	err = parser.Parse()
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	parser.Execute()
-
-	dot_string, err := Asset("templates/dot.tmpl")
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	dot_tables_string, err := Asset("templates/dot_tables.tmpl")
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	dot_relations_string, err := Asset("templates/dot_relations.tmpl")
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	html_string, err := Asset("templates/html.tmpl")
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	pg_ddl_string, err := Asset("templates/pg_ddl.tmpl")
-	if err != nil {
-		fmt.Println(err)
-		return
+	// 相対パスとフルパスのように書き方が違っても同じファイルなら上書きせず、dbml だけ書き出さない
+	if formats["dbml"] {
+		if out_stat, err := os.Stat(path.Join(*output_dir, basename + ".dbml")); err == nil && os.SameFile(input_stat, out_stat) {
+			fmt.Fprintln(os.Stderr, "dbml output is skipped because it would overwrite the input file: " + input_file)
+			delete(formats, "dbml")
+		}
 	}
-	sqlite3_ddl_string, err := Asset("templates/sqlite3_ddl.tmpl")
+	erd, err := loadErdM(input_file)
 	if err != nil {
-		fmt.Println(err)
-		return
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
-	// dot/SQL は raw text（text/template）。html だけは context-aware に
-	// HTML エスケープしたいので html/template を使う。
-	t, err := template.New("template").Parse(string(dot_string) + string(dot_tables_string) + string(dot_relations_string) + string(pg_ddl_string) + string(sqlite3_ddl_string))
-	if err != nil {
-		fmt.Println(err)
-		return
+	type_diagnostics := schema.Diagnostics(erd.CheckTypes(formatDialects(formats), *strict_types))
+	type_diagnostics.SetFile(input_file)
+	for _, d := range type_diagnostics {
+		fmt.Fprintln(os.Stderr, d)
 	}
-	htmlT, err := htmltemplate.New("html").Parse(string(html_string))
-	if err != nil {
-		fmt.Println(err)
-		return
+	if type_diagnostics.HasError() {
+		return 1
 	}
 
+	// png/svg は dot ファイルから作るので、dot を出力しない場合も一時ファイルに書き出す
 	dot_filename := path.Join(*output_dir, basename + ".dot")
-	_, err = os.Stat(dot_filename)
-	if err == nil {
-		if err = os.Remove(dot_filename); err != nil {
+	if needsGraphviz(formats) && !formats["dot"] {
+		tmp, err := ioutil.TempFile("", "erdm-*.dot")
+		if err != nil {
 			fmt.Println(err)
-			return
+			return 1
 		}
-	}
-	fp, err = os.OpenFile(dot_filename, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	err = t.ExecuteTemplate(fp, "dot", parser.ErdM)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	png_filename := path.Join(*output_dir, basename + ".png")
-	err = exec.Command("dot", "-T", "png", "-o", png_filename, dot_filename).Run()
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	parser.ErdM.ImageFilename = path.Base(png_filename)
-
-	html_filename := path.Join(*output_dir, basename + ".html")
-	_, err = os.Stat(html_filename)
-	if err == nil {
-		if err = os.Remove(html_filename); err != nil {
+		tmp.Close()
+		dot_filename = tmp.Name()
+		defer os.Remove(dot_filename)
+	}
+	if formats["dot"] || needsGraphviz(formats) {
+		err = writeFile(dot_filename, func(w io.Writer) error {
+			return schema.Render("dot", erd, w)
+		})
+		if err != nil {
 			fmt.Println(err)
-			return
+			return 1
 		}
 	}
-	fp, err = os.OpenFile(html_filename, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println(err)
-		return
+	if formats["png"] {
+		png_filename := path.Join(*output_dir, basename + ".png")
+		err = exec.Command("dot", "-T", "png", "-o", png_filename, dot_filename).Run()
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		erd.ImageFilename = path.Base(png_filename)
 	}
-	err = htmlT.ExecuteTemplate(fp, "html", parser.ErdM)
-	if err != nil {
-		fmt.Println(err)
-		return
+	if formats["svg"] {
+		svg_filename := path.Join(*output_dir, basename + ".svg")
+		err = exec.Command("dot", "-T", "svg", "-o", svg_filename, dot_filename).Run()
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		svg, err := readSVG(svg_filename)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		erd.ImageSVG = htmltemplate.HTML(svg)
 	}
 
-	pgsql_filename := path.Join(*output_dir, basename + ".pg.sql")
-	_, err = os.Stat(pgsql_filename)
-	if err == nil {
-		if err = os.Remove(pgsql_filename); err != nil {
+	if formats["html"] {
+		err = writeFile(path.Join(*output_dir, basename + ".html"), func(w io.Writer) error {
+			return schema.Render("html", erd, w)
+		})
+		if err != nil {
 			fmt.Println(err)
-			return
+			return 1
 		}
 	}
-	fp, err = os.OpenFile(pgsql_filename, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	err = t.ExecuteTemplate(fp, "pg_ddl", parser.ErdM)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
 
-	sqlite3_filename := path.Join(*output_dir, basename + ".sqlite3.sql")
-	_, err = os.Stat(sqlite3_filename)
-	if err == nil {
-		if err = os.Remove(sqlite3_filename); err != nil {
+	for _, o := range outputFormats {
+		// dot/png/svg/html は上で生成済み
+		if !formats[o.Name] || o.Name == "dot" || o.Name == "png" || o.Name == "svg" || o.Name == "html" {
+			continue
+		}
+		err = writeFile(path.Join(*output_dir, basename + o.Extension), func(w io.Writer) error {
+			return schema.Render(o.Name, erd, w)
+		})
+		if err != nil {
 			fmt.Println(err)
-			return
+			return 1
 		}
 	}
-	fp, err = os.OpenFile(sqlite3_filename, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	err = t.ExecuteTemplate(fp, "sqlite3_ddl", parser.ErdM)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	return 0
 }
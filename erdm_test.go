@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testDBML = `Project p {
+  database_type: 'PostgreSQL'
+}
+
+Enum status {
+  active
+}
+
+Table users {
+  id bigint [pk]
+  name text
+}
+
+TableGroup g {
+  users
+}
+`
+
+// runGenerate は erdm コマンドを args で実行したときの終了コードを返す。
+func runGenerate(t *testing.T, args ...string) int {
+	t.Helper()
+	oldArgs, oldFlags := os.Args, flag.CommandLine
+	defer func() { os.Args, flag.CommandLine = oldArgs, oldFlags }()
+	os.Args = append([]string{"erdm"}, args...)
+	flag.CommandLine = flag.NewFlagSet("erdm", flag.ExitOnError)
+	return generateMain()
+}
+
+// fakeGraphviz は -o に空の画像を書くだけの dot コマンドを PATH の先頭に置く。
+func fakeGraphviz(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\nwhile [ $# -gt 0 ]; do\n  if [ \"$1\" = -o ]; then echo '<svg></svg>' > \"$2\"; fi\n  shift\ndone\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "dot"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestGenerateDoesNotOverwriteDBMLInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		output string
+	}{
+		{"default formats", []string{"schema.dbml"}, "schema.html"},
+		{"default output_dir", []string{"-formats", "dbml,pg", "schema.dbml"}, "schema.pg.sql"},
+		{"explicit output_dir", []string{"-output_dir", ".", "-formats", "dbml", "./schema.dbml"}, ""},
+		{"without dbml", []string{"-formats", "pg", "schema.dbml"}, "schema.pg.sql"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGraphviz(t)
+			dir := t.TempDir()
+			t.Chdir(dir)
+			if err := ioutil.WriteFile("schema.dbml", []byte(testDBML), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if status := runGenerate(t, tt.args...); status != 0 {
+				t.Errorf("exit status = %d, want 0", status)
+			}
+			if len(tt.output) > 0 {
+				if _, err := os.Stat(tt.output); err != nil {
+					t.Errorf("%s is not written: %v", tt.output, err)
+				}
+			}
+			bs, err := ioutil.ReadFile(filepath.Join(dir, "schema.dbml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(bs) != testDBML {
+				t.Errorf("input file was rewritten:\n%s", bs)
+			}
+		})
+	}
+}
+
+func TestGenerateChecksTypesOnlyForDDL(t *testing.T) {
+	const src = "# Title: t\n\nt\n    +id [tinyint][NN]\n    ip [inet]\n    price [money]\n    shape [geometry]\n"
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+	}{
+		{"html", []string{"-formats", "html", "schema.erdm"}, 0},
+		{"pg", []string{"-formats", "html,pg", "schema.erdm"}, 0},
+		{"strict html", []string{"-formats", "html", "-strict_types", "schema.erdm"}, 0},
+		{"strict pg", []string{"-formats", "html,pg", "-strict_types", "schema.erdm"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := ioutil.WriteFile("schema.erdm", []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if status := runGenerate(t, tt.args...); status != tt.status {
+				t.Errorf("exit status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestReadSVG(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "erd.svg")
+	src := "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\"\n \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n<svg width=\"8pt\"><g/></svg>\n"
+	if err := ioutil.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := readSVG(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "<svg width=\"8pt\"><g/></svg>\n" {
+		t.Errorf("readSVG = %q", s)
+	}
+}
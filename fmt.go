@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/unok/erdm/schema"
+)
+
+// fmt: .erdm を読み込み、決まった書式（writeErdM の書式）で書き直す。
+
+// formatErdM は content を .erdm の決まった書式にして返す。エラーがあれば書き直さない。
+func formatErdM(filename string, content []byte) (string, error) {
+	erd, ds := schema.ParsePartial(bytes.NewReader(content))
+	diagnostics := schema.Diagnostics(ds)
+	diagnostics.SetFile(filename)
+	if diagnostics.HasError() {
+		return "", diagnostics
+	}
+	b := &strings.Builder{}
+	err := schema.Render("erdm", erd, b)
+	return b.String(), err
+}
+
+func fmtMain(args []string) int {
+	usage := "Usage: erdm fmt [-w | -check] schema.erdm..."
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write the result to the file instead of standard output")
+	check := fs.Bool("check", false, "list the files that are not formatted and exit with 1 if there are any")
+	files := parseInterspersed(fs, args)
+	if len(files) == 0 || (*write && *check) {
+		fmt.Println(usage)
+		return 1
+	}
+	status := 0
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+		formatted, err := formatErdM(f, content)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+		switch {
+		case *check:
+			if formatted != string(content) {
+				fmt.Println(f)
+				status = 1
+			}
+		case *write:
+			if formatted == string(content) {
+				continue
+			}
+			stat, err := os.Stat(f)
+			if err == nil {
+				err = ioutil.WriteFile(f, []byte(formatted), stat.Mode().Perm())
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				status = 1
+			}
+		default:
+			fmt.Print(formatted)
+		}
+	}
+	return status
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestFmt(t *testing.T) {
+	t.Chdir(t.TempDir())
+	src := "# Title: x\n\nusers\n  +id [int][NN]\n  name   [text]\n"
+	want := "# Title: x\n\nusers\n    +id  [int][NN]\n    name [text]\n"
+	if err := ioutil.WriteFile("a.erdm", []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := fmtMain([]string{"-check", "a.erdm"}); code != 1 {
+		t.Errorf("fmt -check of an unformatted file = %d", code)
+	}
+	if code := fmtMain([]string{"-w", "a.erdm"}); code != 0 {
+		t.Errorf("fmt -w = %d", code)
+	}
+	if bs, _ := ioutil.ReadFile("a.erdm"); string(bs) != want {
+		t.Errorf("a.erdm =\n%s\nwant\n%s", bs, want)
+	}
+	if code := fmtMain([]string{"-check", "a.erdm"}); code != 0 {
+		t.Errorf("fmt -check of a formatted file = %d", code)
+	}
+
+	// エラーのあるファイルは書き直さない
+	broken := "# Title: x\n\nusers\n  +id [int\n"
+	if err := ioutil.WriteFile("b.erdm", []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := fmtMain([]string{"-w", "b.erdm"}); code != 1 {
+		t.Errorf("fmt -w of a broken file = %d", code)
+	}
+	if bs, _ := ioutil.ReadFile("b.erdm"); string(bs) != broken {
+		t.Errorf("b.erdm was rewritten:\n%s", bs)
+	}
+}
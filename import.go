@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/unok/erdm/schema"
+)
+
+// import-sql: pg_dump --schema-only などの PostgreSQL の DDL から .erdm を作る。
+// import-sqlite: SQLite のデータベースファイルから .erdm を作る。
+
+func importSQLMain(args []string) int {
+	usage := "Usage: erdm import-sql [-o output.erdm] [-title title] dump.sql"
+	fs := flag.NewFlagSet("import-sql", flag.ExitOnError)
+	output_file := fs.String("o", "", "output .erdm file (default: standard output)")
+	title := fs.String("title", "", "title of the ERD (default: base name of the input file)")
+	files := parseInterspersed(fs, args)
+	if len(files) != 1 {
+		fmt.Println(usage)
+		return 1
+	}
+	input_file := files[0]
+	content, err := ioutil.ReadFile(input_file)
+	if err != nil {
+		fmt.Println(err)
+		fmt.Println(usage)
+		return 1
+	}
+	erd, warnings, err := schema.ReadSQL(string(content))
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return writeImported(erd, warnings, input_file, *title, *output_file)
+}
+
+// writeImported は取り込んだ Schema を .erdm として書き出す。output_file が空なら標準出力に書く。
+func writeImported(erd *schema.Schema, warnings []string, input_file, title, output_file string) int {
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, w)
+	}
+	erd.Title = title
+	if len(erd.Title) == 0 {
+		f := filepath.Base(input_file)
+		erd.Title = f[:len(f)-len(path.Ext(f))]
+	}
+	var err error
+	if len(output_file) == 0 {
+		err = schema.Render("erdm", erd, os.Stdout)
+	} else {
+		err = writeFile(output_file, func(w io.Writer) error {
+			return schema.Render("erdm", erd, w)
+		})
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func importSQLiteMain(args []string) int {
+	usage := "Usage: erdm import-sqlite [-o output.erdm] [-title title] app.db"
+	fs := flag.NewFlagSet("import-sqlite", flag.ExitOnError)
+	output_file := fs.String("o", "", "output .erdm file (default: standard output)")
+	title := fs.String("title", "", "title of the ERD (default: base name of the input file)")
+	files := parseInterspersed(fs, args)
+	if len(files) != 1 {
+		fmt.Println(usage)
+		return 1
+	}
+	if err := schema.CheckSQLite(); err != nil {
+		fmt.Println(err)
+		fmt.Println("Please check a sqlite3 command setting.")
+		return 1
+	}
+	input_file := files[0]
+	if _, err := os.Stat(input_file); err != nil {
+		fmt.Println(err)
+		fmt.Println(usage)
+		return 1
+	}
+	erd, warnings, err := schema.ReadSQLite(input_file)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return writeImported(erd, warnings, input_file, *title, *output_file)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestImportSQLFlagsAfterInput(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := ioutil.WriteFile("dump.sql", []byte("CREATE TABLE t (id int PRIMARY KEY, tags text[]);\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := importSQLMain([]string{"dump.sql", "-o", "x.erdm", "-title", "x"}); code != 0 {
+		t.Fatalf("import-sql = %d", code)
+	}
+	bs, err := ioutil.ReadFile("x.erdm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Title: x\n\nt\n    +id  [int][NN]\n    tags [text array]\n"
+	if string(bs) != want {
+		t.Errorf("x.erdm =\n%s\nwant\n%s", bs, want)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/unok/erdm/schema"
+)
+
+const defaultLintConfigFile = ".erdm-lint.json"
+
+func lintMain(args []string) int {
+	usage := "Usage: erdm lint [-config " + defaultLintConfigFile + "] [-rules] schema.erdm..."
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	config_file := fs.String("config", "", "lint configuration file (default: "+defaultLintConfigFile+" if it exists)")
+	list_rules := fs.Bool("rules", false, "list the rules")
+	files := parseInterspersed(fs, args)
+	if *list_rules {
+		for _, r := range schema.LintRules() {
+			fmt.Printf("%-14s %s\n", r.Name, r.Description)
+		}
+		return 0
+	}
+	if len(files) == 0 {
+		fmt.Println(usage)
+		return 1
+	}
+	config := &schema.LintConfig{}
+	if len(*config_file) == 0 {
+		if _, err := os.Stat(defaultLintConfigFile); err == nil {
+			*config_file = defaultLintConfigFile
+		}
+	}
+	if len(*config_file) > 0 {
+		c, err := schema.LoadLintConfig(*config_file)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		config = c
+	}
+	status := 0
+	for _, f := range files {
+		erd, err := loadErdM(f)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+			continue
+		}
+		ds := schema.Diagnostics(erd.Lint(config))
+		ds.SetFile(f)
+		for _, d := range ds {
+			fmt.Println(d)
+		}
+		if ds.HasError() {
+			status = 1
+		}
+	}
+	return status
+}
@@ -0,0 +1,548 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/unok/erdm/schema"
+)
+
+// lsp: エディタ向けに Language Server Protocol を標準入出力で話す。
+// 文書は変更のたびに内容を丸ごと受け取り（textDocumentSync: Full）、読み直して診断を送る。
+// 補完・定義・ホバー・シンボルには、エラーがあっても読めたところまでの Schema を使う。
+// 型の診断は initializationOptions の formats / strict_types で erdm の -formats / -strict_types と同じように行う。
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspCompletionItem struct {
+	Label  string `json:"label"`
+	Kind   int    `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+	Range    lspRange         `json:"range"`
+}
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspDocumentSymbol struct {
+	Name           string              `json:"name"`
+	Detail         string              `json:"detail,omitempty"`
+	Kind           int                 `json:"kind"`
+	Range          lspRange            `json:"range"`
+	SelectionRange lspRange            `json:"selectionRange"`
+	Children       []lspDocumentSymbol `json:"children,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// lspRequest はクライアントからのリクエストと通知。通知には ID がない。
+type lspRequest struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+// lspParams は使うメソッドのパラメータをまとめて受ける。
+type lspParams struct {
+	TextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Position              lspPosition `json:"position"`
+	InitializationOptions struct {
+		Formats     string `json:"formats"`
+		StrictTypes bool   `json:"strict_types"`
+	} `json:"initializationOptions"`
+}
+
+const (
+	lspSeverityError      = 1
+	lspSeverityWarning    = 2
+	lspCompletionField    = 5
+	lspCompletionClass    = 7
+	lspSymbolField        = 8
+	lspSymbolStruct       = 23
+	lspErrorInvalidParams = -32602
+	lspErrorNotFound      = -32601
+)
+
+type lspDocument struct {
+	lines []string
+	erd   *schema.Schema
+}
+
+type lspServer struct {
+	w         io.Writer
+	documents map[string]*lspDocument
+	shutdown  bool
+	// dialects が空なら型は確かめない
+	dialects    []string
+	strictTypes bool
+}
+
+func lspMain(args []string) int {
+	if len(args) > 0 && args[0] != "--stdio" {
+		fmt.Println("Usage: erdm lsp")
+		return 1
+	}
+	s := &lspServer{w: os.Stdout, documents: map[string]*lspDocument{}}
+	return s.serve(os.Stdin)
+}
+
+// readLSPMessage は Content-Length ヘッダの付いたメッセージを 1 つ読む。
+func readLSPMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			break
+		}
+		if i := strings.Index(line, ":"); i >= 0 && strings.EqualFold(strings.TrimSpace(line[:i]), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[i+1:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %s", line)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length")
+	}
+	body := make([]byte, length)
+	_, err := io.ReadFull(r, body)
+	return body, err
+}
+
+// serve は exit 通知か入力の終わりまでリクエストを処理し、終了コードを返す。
+func (s *lspServer) serve(in io.Reader) int {
+	r := bufio.NewReader(in)
+	for {
+		body, err := readLSPMessage(r)
+		if err != nil {
+			if err != io.EOF {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return 1
+		}
+		req := lspRequest{}
+		if err = json.Unmarshal(body, &req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		if req.Method == "exit" {
+			if s.shutdown {
+				return 0
+			}
+			return 1
+		}
+		result, rerr := s.handle(req.Method, req.Params)
+		if req.ID == nil {
+			continue
+		}
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rerr != nil {
+			response["error"] = rerr
+		} else {
+			response["result"] = result
+		}
+		s.send(response)
+	}
+}
+
+func (s *lspServer) send(v interface{}) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(bs), bs)
+}
+
+func (s *lspServer) handle(method string, raw json.RawMessage) (interface{}, *lspError) {
+	params := lspParams{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, &lspError{Code: lspErrorInvalidParams, Message: err.Error()}
+		}
+	}
+	uri := params.TextDocument.URI
+	d := s.documents[uri]
+	switch method {
+	case "initialize":
+		if o := params.InitializationOptions; len(o.Formats) > 0 {
+			formats, err := parseFormats(o.Formats)
+			if err != nil {
+				return nil, &lspError{Code: lspErrorInvalidParams, Message: err.Error()}
+			}
+			s.dialects, s.strictTypes = formatDialects(formats), o.StrictTypes
+		}
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":       1,
+				"completionProvider":     map[string]interface{}{"triggerCharacters": []string{" ", "-", ".", "(", ","}},
+				"definitionProvider":     true,
+				"hoverProvider":          true,
+				"documentSymbolProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "erdm"},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		s.update(uri, params.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(uri, params.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		delete(s.documents, uri)
+		s.publish(uri, []lspDiagnostic{})
+		return nil, nil
+	case "textDocument/completion":
+		if d == nil {
+			return []lspCompletionItem{}, nil
+		}
+		return d.completion(params.Position), nil
+	case "textDocument/definition":
+		if d == nil {
+			return nil, nil
+		}
+		return d.definition(uri, params.Position), nil
+	case "textDocument/hover":
+		if d == nil {
+			return nil, nil
+		}
+		return d.hover(params.Position), nil
+	case "textDocument/documentSymbol":
+		if d == nil {
+			return []lspDocumentSymbol{}, nil
+		}
+		return d.symbols(), nil
+	}
+	return nil, &lspError{Code: lspErrorNotFound, Message: "method not found: " + method}
+}
+
+// update は文書を読み直して診断を送る。
+func (s *lspServer) update(uri, text string) {
+	erd, ds := schema.ParsePartial(strings.NewReader(text))
+	ds = append(ds, erd.CheckTypes(s.dialects, s.strictTypes)...)
+	d := &lspDocument{lines: strings.Split(text, "\n"), erd: erd}
+	s.documents[uri] = d
+	diagnostics := []lspDiagnostic{}
+	for _, x := range ds {
+		diagnostics = append(diagnostics, d.diagnostic(x))
+	}
+	s.publish(uri, diagnostics)
+}
+
+func (s *lspServer) publish(uri string, diagnostics []lspDiagnostic) {
+	s.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/publishDiagnostics",
+		"params":  map[string]interface{}{"uri": uri, "diagnostics": diagnostics},
+	})
+}
+
+// line は 0 始まりの line 行目を改行を除いて返す。
+func (d *lspDocument) line(line int) string {
+	if line < 0 || line >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimRight(d.lines[line], "\r")
+}
+
+// utf16Column は rune 単位の桁を LSP の UTF-16 単位の桁にする。どちらも 0 始まり。
+func utf16Column(line string, column int) int {
+	rs := []rune(line)
+	if column > len(rs) {
+		column = len(rs)
+	}
+	return len(utf16.Encode(rs[:column]))
+}
+
+// runeColumn は LSP の UTF-16 単位の桁を rune 単位の桁にする。
+func runeColumn(line string, character int) int {
+	n := 0
+	rs := []rune(line)
+	for i, r := range rs {
+		if n >= character {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	return len(rs)
+}
+
+// rangeAt は pos（1 始まりの行と rune 単位の桁）から length 文字の範囲を返す。
+func (d *lspDocument) rangeAt(pos schema.Position, length int) lspRange {
+	if pos.Line == 0 {
+		return lspRange{}
+	}
+	line := d.line(pos.Line - 1)
+	return lspRange{
+		Start: lspPosition{Line: pos.Line - 1, Character: utf16Column(line, pos.Column-1)},
+		End:   lspPosition{Line: pos.Line - 1, Character: utf16Column(line, pos.Column-1+length)},
+	}
+}
+
+// wordAt は line の column 文字目を含む識別子とその開始位置を返す。
+func wordAt(line string, column int) (string, int) {
+	rs := []rune(line)
+	isWord := func(i int) bool {
+		return i >= 0 && i < len(rs) && (rs[i] == '_' || ('a' <= rs[i] && rs[i] <= 'z') || ('A' <= rs[i] && rs[i] <= 'Z') || ('0' <= rs[i] && rs[i] <= '9'))
+	}
+	start, end := column, column
+	for isWord(start - 1) {
+		start--
+	}
+	for isWord(end) {
+		end++
+	}
+	return string(rs[start:end]), start
+}
+
+func (d *lspDocument) diagnostic(x schema.Diagnostic) lspDiagnostic {
+	length := 0
+	if x.Line > 0 {
+		rs := []rune(d.line(x.Line - 1))
+		for i := x.Column - 1; i >= 0 && i < len(rs) && rs[i] != ' ' && rs[i] != '\t'; i++ {
+			length++
+		}
+	}
+	severity := lspSeverityError
+	if x.Severity == schema.SeverityWarning {
+		severity = lspSeverityWarning
+	}
+	message := x.Message
+	if len(x.Expected) > 0 {
+		message += " (expected " + strings.Join(x.Expected, ", ") + ")"
+	}
+	return lspDiagnostic{Range: d.rangeAt(schema.Position{Line: x.Line, Column: x.Column}, length), Severity: severity, Code: x.Rule, Source: "erdm", Message: message}
+}
+
+func (d *lspDocument) findTable(name string) *schema.Table {
+	for ti := range d.erd.Tables {
+		if d.erd.Tables[ti].TitleReal == name {
+			return &d.erd.Tables[ti]
+		}
+	}
+	return nil
+}
+
+// tableAt は 0 始まりの line 行目を含むテーブルを返す。
+func (d *lspDocument) tableAt(line int) *schema.Table {
+	var t *schema.Table
+	for ti := range d.erd.Tables {
+		if d.erd.Tables[ti].Position.Line-1 <= line {
+			t = &d.erd.Tables[ti]
+		}
+	}
+	return t
+}
+
+var (
+	lspRelationPattern       = regexp.MustCompile(`--[ \t]*(?:[01*](?:\.\.[01*])?[ \t])?[ \t]*[A-Za-z0-9_]*$`)
+	lspRelationColumnPattern = regexp.MustCompile(`--[ \t]*(?:[01*](?:\.\.[01*])?[ \t])?[ \t]*([A-Za-z0-9_]+)\.[A-Za-z0-9_]*$`)
+	lspIndexPattern          = regexp.MustCompile(`^[ \t]+index[ \t]+[A-Za-z0-9_]+[ \t]*\([^)]*$`)
+	lspFkPattern             = regexp.MustCompile(`(?i)^[ \t]+fk[ \t]*\([^)]*$`)
+	lspFkReferencePattern    = regexp.MustCompile(`--[ \t]*(?:[01*](?:\.\.[01*])?[ \t])?[ \t]*([A-Za-z0-9_]+)[ \t]*\([^)]*$`)
+)
+
+// completion はリレーションの -- の後ろでテーブル名（table. の後ろではカラム名）を、
+// index (...) と fk (...) の中ではそのテーブルの、fk の参照先の (...) の中では参照先テーブルのカラム名を補完する。
+func (d *lspDocument) completion(p lspPosition) []lspCompletionItem {
+	line := d.line(p.Line)
+	before := string([]rune(line)[:runeColumn(line, p.Character)])
+	items := []lspCompletionItem{}
+	columns := func(t *schema.Table) {
+		if t == nil {
+			return
+		}
+		for _, c := range t.Columns {
+			items = append(items, lspCompletionItem{Label: c.TitleReal, Kind: lspCompletionField, Detail: strings.TrimSpace(c.Title + " [" + c.Type + "]")})
+		}
+	}
+	switch {
+	case lspFkReferencePattern.MatchString(before):
+		columns(d.findTable(lspFkReferencePattern.FindStringSubmatch(before)[1]))
+	case lspRelationColumnPattern.MatchString(before):
+		columns(d.findTable(lspRelationColumnPattern.FindStringSubmatch(before)[1]))
+	case lspRelationPattern.MatchString(before):
+		for _, t := range d.erd.Tables {
+			items = append(items, lspCompletionItem{Label: t.TitleReal, Kind: lspCompletionClass, Detail: t.Title})
+		}
+	case lspIndexPattern.MatchString(before), lspFkPattern.MatchString(before):
+		columns(d.tableAt(p.Line))
+	}
+	return items
+}
+
+// definition はリレーションの参照先からテーブル（table.column や fk の参照先の (...) ならカラム）の定義に移る。
+func (d *lspDocument) definition(uri string, p lspPosition) interface{} {
+	column := runeColumn(d.line(p.Line), p.Character)
+	for _, t := range d.erd.Tables {
+		for _, c := range t.Columns {
+			r := c.Relation
+			if !c.HasRelation() || r.Position.Line-1 != p.Line {
+				continue
+			}
+			start := r.Position.Column - 1
+			end := start + len(r.TableNameReal)
+			if len(r.ColumnNameReal) > 0 {
+				end += 1 + len(r.ColumnNameReal)
+			}
+			if column < start || column > end {
+				continue
+			}
+			target := d.findTable(r.TableNameReal)
+			if target == nil {
+				return nil
+			}
+			if len(r.ColumnNameReal) > 0 && column > start+len(r.TableNameReal) {
+				for _, tc := range target.Columns {
+					if tc.TitleReal == r.ColumnNameReal {
+						return lspLocation{URI: uri, Range: d.rangeAt(tc.Position, len(tc.TitleReal))}
+					}
+				}
+			}
+			return lspLocation{URI: uri, Range: d.rangeAt(target.Position, len(target.TitleReal))}
+		}
+		for _, r := range t.CompositeRelations {
+			if r.TablePosition.Line-1 != p.Line || column < r.TablePosition.Column-1 {
+				continue
+			}
+			target := d.findTable(r.TableNameReal)
+			if target == nil {
+				return nil
+			}
+			// fk (...) の参照先の (...) の中ならカラムに移る
+			word, _ := wordAt(d.line(p.Line), column)
+			if column > r.TablePosition.Column-1+len(r.TableNameReal) && slices.Contains(r.ReferenceColumns, word) {
+				for _, tc := range target.Columns {
+					if tc.TitleReal == word {
+						return lspLocation{URI: uri, Range: d.rangeAt(tc.Position, len(tc.TitleReal))}
+					}
+				}
+			}
+			return lspLocation{URI: uri, Range: d.rangeAt(target.Position, len(target.TitleReal))}
+		}
+	}
+	return nil
+}
+
+// hover はカーソルのあるテーブル名について論理名と説明、カラムを表示する。
+func (d *lspDocument) hover(p lspPosition) interface{} {
+	line := d.line(p.Line)
+	word, start := wordAt(line, runeColumn(line, p.Character))
+	t := d.findTable(word)
+	if t == nil {
+		return nil
+	}
+	b := &strings.Builder{}
+	b.WriteString("**" + t.TitleReal + "**")
+	if len(t.Title) > 0 {
+		b.WriteString(" " + t.Title)
+	}
+	for _, tc := range t.Comments {
+		b.WriteString("\n\n" + tc)
+	}
+	b.WriteString("\n\n```erdm\n")
+	for _, c := range t.Columns {
+		b.WriteString(c.GetErdmHead() + " " + c.GetErdmAttributes() + "\n")
+	}
+	for _, r := range t.CompositeRelations {
+		b.WriteString(r.GetErdmText() + "\n")
+	}
+	b.WriteString("```\n")
+	return lspHover{
+		Contents: lspMarkupContent{Kind: "markdown", Value: b.String()},
+		Range: lspRange{
+			Start: lspPosition{Line: p.Line, Character: utf16Column(line, start)},
+			End:   lspPosition{Line: p.Line, Character: utf16Column(line, start+len(word))},
+		},
+	}
+}
+
+// symbols はテーブルごとにシンボルを返す。カラムは子になる。
+func (d *lspDocument) symbols() []lspDocumentSymbol {
+	ss := []lspDocumentSymbol{}
+	for ti, t := range d.erd.Tables {
+		// テーブルは次のテーブルの直前まで。後ろの空行と // コメントは含めない
+		start := t.Position.Line - 1
+		end := len(d.lines) - 1
+		if ti+1 < len(d.erd.Tables) {
+			end = d.erd.Tables[ti+1].Position.Line - 2
+		}
+		for end > start && (len(strings.TrimSpace(d.line(end))) == 0 || strings.HasPrefix(strings.TrimSpace(d.line(end)), "//")) {
+			end--
+		}
+		s := lspDocumentSymbol{
+			Name:           t.TitleReal,
+			Detail:         t.Title,
+			Kind:           lspSymbolStruct,
+			Range:          lspRange{Start: lspPosition{Line: start}, End: lspPosition{Line: end, Character: utf16Column(d.line(end), len([]rune(d.line(end))))}},
+			SelectionRange: d.rangeAt(t.Position, len(t.TitleReal)),
+		}
+		for _, c := range t.Columns {
+			line := d.line(c.Position.Line - 1)
+			s.Children = append(s.Children, lspDocumentSymbol{
+				Name:           c.TitleReal,
+				Detail:         strings.TrimSpace(c.Title + " [" + c.Type + "]"),
+				Kind:           lspSymbolField,
+				Range:          lspRange{Start: lspPosition{Line: c.Position.Line - 1}, End: lspPosition{Line: c.Position.Line - 1, Character: utf16Column(line, len([]rune(line)))}},
+				SelectionRange: d.rangeAt(c.Position, len(c.TitleReal)),
+			})
+		}
+		ss = append(ss, s)
+	}
+	return ss
+}
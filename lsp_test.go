@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testLSPSource = "# Title: t\n\nusers/\"site user\"\n    # members\n    +id [bigint][NN]\n    code [text][U]\n\narticles\n    +id [bigint][NN]\n    user_id [bigint] 0..*--1 users\n    user_code [text] 0..*--1 users.code\n    fk (id, user_id) 0..*--1 users (id, code)\n    index i_articles_user_id (user_id)\n"
+
+// lspMessages は requests を 1 つずつ Content-Length 付きで送り、サーバが書き出したメッセージと終了コードを返す。
+func lspMessages(t *testing.T, requests ...string) ([]map[string]interface{}, int) {
+	t.Helper()
+	in := &strings.Builder{}
+	for _, r := range requests {
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(r), r)
+	}
+	out := &strings.Builder{}
+	s := &lspServer{w: out, documents: map[string]*lspDocument{}}
+	code := s.serve(strings.NewReader(in.String()))
+	ms := []map[string]interface{}{}
+	r := bufio.NewReader(strings.NewReader(out.String()))
+	for {
+		body, err := readLSPMessage(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := map[string]interface{}{}
+		if err = json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		ms = append(ms, m)
+	}
+	return ms, code
+}
+
+func lspOpen(text string) string {
+	bs, _ := json.Marshal(text)
+	return `{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///t.erdm","text":` + string(bs) + `}}}`
+}
+
+func TestLSPSession(t *testing.T) {
+	ms, code := lspMessages(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		lspOpen("# Title: t\n\nusers\n    +id [int][NN] 0..*--1 nope\n"),
+		`{"jsonrpc":"2.0","id":2,"method":"no/such"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	if code != 0 {
+		t.Errorf("exit code = %d", code)
+	}
+	if len(ms) != 4 {
+		t.Fatalf("messages = %v", ms)
+	}
+	if _, ok := ms[0]["result"].(map[string]interface{})["capabilities"]; !ok {
+		t.Errorf("initialize = %v", ms[0])
+	}
+	bs, _ := json.Marshal(ms[1]["params"])
+	want := `{"diagnostics":[{"message":"users.id references unknown table nope","range":{"end":{"character":30,"line":3},"start":{"character":26,"line":3}},"severity":1,"source":"erdm"}],"uri":"file:///t.erdm"}`
+	if string(bs) != want {
+		t.Errorf("publishDiagnostics = %s\nwant %s", bs, want)
+	}
+	if e, ok := ms[2]["error"].(map[string]interface{}); !ok || e["code"] != float64(lspErrorNotFound) {
+		t.Errorf("unknown method = %v", ms[2])
+	}
+
+	// shutdown の前の exit は失敗
+	if _, code := lspMessages(t, `{"jsonrpc":"2.0","method":"exit"}`); code != 1 {
+		t.Errorf("exit code without shutdown = %d", code)
+	}
+}
+
+func testLSPDocument(t *testing.T) *lspDocument {
+	t.Helper()
+	s := &lspServer{w: io.Discard, documents: map[string]*lspDocument{}}
+	s.update("file:///t.erdm", testLSPSource)
+	return s.documents["file:///t.erdm"]
+}
+
+func TestLSPTypeDiagnostics(t *testing.T) {
+	src := "# Title: t\n\nt\n    +id [int][NN]\n    at [time]\n    shape [geometry]\n"
+	tests := []struct {
+		name    string
+		options string
+		want    []string
+	}{
+		{"no formats", `{}`, nil},
+		{"pg", `{"formats":"html,pg"}`, []string{`unknown type "geometry" of t.shape is written verbatim`}},
+		{"strict oracle", `{"formats":"oracle","strict_types":true}`, []string{`type "time" of t.at has no mapping for oracle; written verbatim`, `unknown type "geometry" of t.shape`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms, _ := lspMessages(t,
+				`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"initializationOptions":`+tt.options+`}}`,
+				lspOpen(src),
+			)
+			if len(ms) != 2 {
+				t.Fatalf("messages = %v", ms)
+			}
+			diagnostics := ms[1]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+			if len(diagnostics) != len(tt.want) {
+				t.Fatalf("diagnostics = %v, want %d", diagnostics, len(tt.want))
+			}
+			for i, d := range diagnostics {
+				if m := d.(map[string]interface{})["message"].(string); m != tt.want[i] {
+					t.Errorf("diagnostic %d = %q, want %q", i, m, tt.want[i])
+				}
+			}
+		})
+	}
+
+	ms, _ := lspMessages(t, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"initializationOptions":{"formats":"nope"}}}`)
+	if e, ok := ms[0]["error"].(map[string]interface{}); !ok || e["code"] != float64(lspErrorInvalidParams) {
+		t.Errorf("initialize with an unknown format = %v", ms[0])
+	}
+}
+
+func TestLSPCompletion(t *testing.T) {
+	d := testLSPDocument(t)
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"table", "    x [int] 0..*--", "users articles"},
+		{"table after cardinality", "    x [int] 0..*--1 us", "users articles"},
+		{"column of the referenced table", "    x [int] 0..*--1 users.", "id code"},
+		{"index", "    index i_x (", "id user_id user_code"},
+		{"fk", "    fk (id, ", "id user_id user_code"},
+		{"fk reference", "    fk (id) 0..*--1 users (", "id code"},
+		{"nothing", "    x [int", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// articles の最後の行に書いている途中とする
+			d.lines[12] = tt.line
+			labels := []string{}
+			for _, item := range d.completion(lspPosition{Line: 12, Character: len(tt.line)}) {
+				labels = append(labels, item.Label)
+			}
+			if got := strings.Join(labels, " "); got != tt.want {
+				t.Errorf("completion = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLSPDefinition(t *testing.T) {
+	d := testLSPDocument(t)
+	tests := []struct {
+		name string
+		p    lspPosition
+		want string
+	}{
+		{"table", lspPosition{Line: 9, Character: 30}, "2:0-2:5"},
+		{"table of table.column", lspPosition{Line: 10, Character: 30}, "2:0-2:5"},
+		{"column of table.column", lspPosition{Line: 10, Character: 35}, "5:4-5:8"},
+		{"fk table", lspPosition{Line: 11, Character: 29}, "2:0-2:5"},
+		{"fk column", lspPosition{Line: 11, Character: 41}, "5:4-5:8"},
+		{"column name", lspPosition{Line: 9, Character: 6}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if l, ok := d.definition("file:///t.erdm", tt.p).(lspLocation); ok {
+				got = fmt.Sprintf("%d:%d-%d:%d", l.Range.Start.Line, l.Range.Start.Character, l.Range.End.Line, l.Range.End.Character)
+			}
+			if got != tt.want {
+				t.Errorf("definition = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLSPHover(t *testing.T) {
+	d := testLSPDocument(t)
+	h, ok := d.hover(lspPosition{Line: 9, Character: 31}).(lspHover)
+	if !ok {
+		t.Fatal("no hover on users")
+	}
+	want := "**users** site user\n\nmembers\n\n```erdm\n+id [bigint][NN]\ncode [text][U]\n```\n"
+	if h.Contents.Value != want {
+		t.Errorf("hover = %q, want %q", h.Contents.Value, want)
+	}
+	if h.Range.Start.Character != 29 || h.Range.End.Character != 34 {
+		t.Errorf("hover range = %+v", h.Range)
+	}
+	if d.hover(lspPosition{Line: 4, Character: 6}) != nil {
+		t.Error("hover on a column name")
+	}
+}
+
+func TestLSPSymbols(t *testing.T) {
+	ss := testLSPDocument(t).symbols()
+	if len(ss) != 2 || ss[0].Name != "users" || ss[0].Detail != "site user" || len(ss[0].Children) != 2 {
+		t.Fatalf("symbols = %+v", ss)
+	}
+	// users は後ろの空行を含めない
+	if ss[0].Range.Start.Line != 2 || ss[0].Range.End.Line != 5 || ss[1].Range.Start.Line != 7 {
+		t.Errorf("ranges = %+v, %+v", ss[0].Range, ss[1].Range)
+	}
+}
+
+func TestUTF16Column(t *testing.T) {
+	// 𩸽 は UTF-16 で 2 単位
+	line := "a𩸽b"
+	for column, character := range []int{0, 1, 3, 4} {
+		if got := utf16Column(line, column); got != character {
+			t.Errorf("utf16Column(%d) = %d, want %d", column, got, character)
+		}
+		if got := runeColumn(line, character); got != column {
+			t.Errorf("runeColumn(%d) = %d, want %d", character, got, column)
+		}
+	}
+}
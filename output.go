@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// outputFormat は出力するファイル。png/svg 以外の内容は schema.Render の同じ名前の形式で書き出す。
+type outputFormat struct {
+	Name      string
+	Extension string
+	Dialect   string
+}
+
+// outputFormats は -formats で指定できる出力。画像を埋め込む html のため、並び順に生成する。
+var outputFormats = []outputFormat{
+	{Name: "dot", Extension: ".dot"},
+	{Name: "png", Extension: ".png"},
+	{Name: "svg", Extension: ".svg"},
+	{Name: "html", Extension: ".html"},
+	{Name: "pg", Extension: ".pg.sql", Dialect: "pg"},
+	{Name: "sqlite3", Extension: ".sqlite3.sql", Dialect: "sqlite3"},
+	{Name: "mysql", Extension: ".mysql.sql", Dialect: "mysql"},
+	{Name: "mssql", Extension: ".mssql.sql", Dialect: "mssql"},
+	{Name: "oracle", Extension: ".oracle.sql", Dialect: "oracle"},
+	{Name: "mermaid", Extension: ".mmd"},
+	{Name: "plantuml", Extension: ".puml"},
+	{Name: "dbml", Extension: ".dbml"},
+	{Name: "json", Extension: ".json"},
+}
+
+// defaultFormats は -formats を指定しないときに書き出す形式。-formats ができる前と同じ。
+var defaultFormats = []string{"dot", "png", "html", "pg", "sqlite3"}
+
+func getFormatNames() []string {
+	ns := []string{}
+	for _, f := range outputFormats {
+		ns = append(ns, f.Name)
+	}
+	return ns
+}
+
+// parseFormats は "pg,sqlite3,html" のような -formats の値を解釈する。
+func parseFormats(s string) (map[string]bool, error) {
+	fs := map[string]bool{}
+	for _, n := range strings.Split(s, ",") {
+		n = strings.TrimSpace(n)
+		if len(n) == 0 {
+			continue
+		}
+		if !slices.Contains(getFormatNames(), n) {
+			return nil, fmt.Errorf("unknown format: %s (available: %s)", n, strings.Join(getFormatNames(), ","))
+		}
+		fs[n] = true
+	}
+	if len(fs) == 0 {
+		return nil, fmt.Errorf("no format is specified")
+	}
+	return fs, nil
+}
+
+// formatDialects は formats のうち DDL の形式の方言を outputFormats の順に返す。
+func formatDialects(formats map[string]bool) []string {
+	ds := []string{}
+	for _, o := range outputFormats {
+		if formats[o.Name] && len(o.Dialect) > 0 {
+			ds = append(ds, o.Dialect)
+		}
+	}
+	return ds
+}
+
+func needsGraphviz(formats map[string]bool) bool {
+	return formats["png"] || formats["svg"]
+}
+
+func checkGraphviz() error {
+	return exec.Command("dot", "-?").Run()
+}
+
+// writeFile は既存のファイルを消してから render の出力で作り直す。
+func writeFile(filename string, render func(w io.Writer) error) error {
+	_, err := os.Stat(filename)
+	if err == nil {
+		if err = os.Remove(filename); err != nil {
+			return err
+		}
+	}
+	fp, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	return render(fp)
+}
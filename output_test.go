@@ -0,0 +1,110 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/unok/erdm/schema"
+)
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		err  string
+	}{
+		{"pg", "pg", ""},
+		{" html , pg,,mermaid ", "html,mermaid,pg", ""},
+		{"pg,pg", "pg", ""},
+		{"", "", "no format is specified"},
+		{",", "", "no format is specified"},
+		{"pg,word", "", "unknown format: word"},
+	}
+	for _, tt := range tests {
+		fs, err := parseFormats(tt.s)
+		if len(tt.err) > 0 {
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("parseFormats(%q) error = %v, want %q", tt.s, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseFormats(%q) error = %v", tt.s, err)
+			continue
+		}
+		ns := []string{}
+		for n := range fs {
+			ns = append(ns, n)
+		}
+		sort.Strings(ns)
+		if got := strings.Join(ns, ","); got != tt.want {
+			t.Errorf("parseFormats(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestOutputFormatsAreRenderable(t *testing.T) {
+	// png と svg は dot コマンドで作るので schema.Render の形式にはない
+	for _, f := range outputFormats {
+		if f.Name != "png" && f.Name != "svg" && !slices.Contains(schema.Formats(), f.Name) {
+			t.Errorf("format %s is not a schema.Render format", f.Name)
+		}
+		if len(f.Dialect) > 0 && !slices.Contains(schema.Dialects(), f.Dialect) {
+			t.Errorf("dialect %s of %s is unknown", f.Dialect, f.Name)
+		}
+	}
+}
+
+func TestGenerateWritesDefaultFormats(t *testing.T) {
+	fakeGraphviz(t)
+	t.Chdir(t.TempDir())
+	if err := ioutil.WriteFile("schema.erdm", []byte("# Title: t\n\nusers\n    +id [bigint][NN]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if status := runGenerate(t, "schema.erdm"); status != 0 {
+		t.Fatalf("exit status = %d", status)
+	}
+	fis, err := ioutil.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns := []string{}
+	for _, fi := range fis {
+		ns = append(ns, fi.Name())
+	}
+	if got := strings.Join(ns, " "); got != "schema.dot schema.erdm schema.html schema.pg.sql schema.png schema.sqlite3.sql" {
+		t.Errorf("output files = %s", got)
+	}
+}
+
+func TestGenerateWritesSelectedFormats(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir("out", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile("schema.erdm", []byte("# Title: t\n\nusers\n    +id [bigint][NN]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if status := runGenerate(t, "-output_dir", "out", "-formats", "pg,mermaid", "schema.erdm"); status != 0 {
+		t.Fatalf("exit status = %d", status)
+	}
+	fis, err := ioutil.ReadDir("out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns := []string{}
+	for _, fi := range fis {
+		ns = append(ns, fi.Name())
+	}
+	if got := strings.Join(ns, " "); got != "schema.mmd schema.pg.sql" {
+		t.Errorf("output files = %s", got)
+	}
+	if status := runGenerate(t, "-formats", "pg,word", "schema.erdm"); status != 1 {
+		t.Errorf("exit status with an unknown format = %d, want 1", status)
+	}
+}
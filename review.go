@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/unok/erdm/schema"
+)
+
+// review: git のリビジョンにある .erdm と作業ツリーの .erdm の差分を要約する。
+
+// gitShow は rev 時点の filename の内容を git show で読む。ファイルが rev になければ ok は false になる。
+func gitShow(rev string, filename string) (content string, ok bool, err error) {
+	dir, base := filepath.Split(filename)
+	if len(dir) == 0 {
+		dir = "."
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--verify", "--quiet", rev+"^{commit}").Output()
+	if err != nil || len(out) == 0 {
+		return "", false, fmt.Errorf("unknown revision: %s", rev)
+	}
+	out, err = exec.Command("git", "-C", dir, "show", rev+":./"+base).Output()
+	if err != nil {
+		return "", false, nil
+	}
+	return string(out), true, nil
+}
+
+func reviewMain(args []string) int {
+	usage := "Usage: erdm review [-rev revision] schema.erdm"
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	rev := fs.String("rev", "HEAD", "git revision to compare with")
+	files := parseInterspersed(fs, args)
+	if len(files) != 1 {
+		fmt.Println(usage)
+		return 1
+	}
+	n, err := loadErdM(files[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	content, ok, err := gitShow(*rev, files[0])
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	o := &schema.Schema{}
+	if ok {
+		o, _, err = parseErdM(files[0], strings.NewReader(content))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, *rev+": "+err.Error())
+			return 1
+		}
+	}
+	r := &schema.Review{Filename: files[0], Rev: *rev, IsNew: !ok, Diff: schema.Diff(o, n)}
+	if err = r.Render(os.Stdout); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
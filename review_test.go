@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitShow(t *testing.T) {
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=erdm", "-c", "user.email=erdm@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+	filename := filepath.Join(dir, "schema.erdm")
+	git("init", "-q")
+	if err := ioutil.WriteFile(filename, []byte("# Title: old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "schema.erdm")
+	git("commit", "-q", "-m", "add schema")
+	if err := ioutil.WriteFile(filename, []byte("# Title: new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		rev      string
+		filename string
+		content  string
+		ok       bool
+		err      bool
+	}{
+		{"committed file", "HEAD", filename, "# Title: old\n", true, false},
+		{"file not in the revision", "HEAD", filepath.Join(dir, "other.erdm"), "", false, false},
+		{"unknown revision", "no-such-rev", filename, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, ok, err := gitShow(tt.rev, tt.filename)
+			if (err != nil) != tt.err || ok != tt.ok || content != tt.content {
+				t.Errorf("gitShow(%s, %s) = %q, %v, %v", tt.rev, tt.filename, content, ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package schema
+
+import "io"
+
+// Changelog は 2 つの .erdm の差分を表す HTML のページ。
+// 各テーブルは DocFilename（新しい .erdm の html 出力）の定義にリンクする。
+type Changelog struct {
+	Title       string
+	OldName     string
+	NewName     string
+	DocFilename string
+	Diff        *SchemaDiff
+}
+
+// Render はページを HTML で w に書き出す。
+func (c *Changelog) Render(w io.Writer) error {
+	_, htmlT, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	return htmlT.ExecuteTemplate(w, "changelog", c)
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChangelog(t *testing.T) {
+	c := &Changelog{Title: "t", OldName: "old.erdm", NewName: "new.erdm", DocFilename: "new.html", Diff: Diff(mustParse(t, testDiffOld), mustParse(t, testDiffNew))}
+	b := &strings.Builder{}
+	if err := c.Render(b); err != nil {
+		t.Fatal(err)
+	}
+	assertInOrder(t, b.String(), []string{
+		`<span class="navbar-text">old.erdm &rarr; new.erdm</span>`,
+		`<span class="badge text-bg-success">Added 1</span>`,
+		`<span class="badge text-bg-danger">Removed 1</span>`,
+		`<span class="badge text-bg-warning">Modified 1</span>`,
+		`<h3><span class="badge text-bg-success">Added</span> <a href="new.html#table-tags">tags</a> </h3>`,
+		`<h3><span class="badge text-bg-danger">Removed</span> <span class="old">groups</span> </h3>`,
+		`<h3><span class="badge text-bg-warning">Modified</span> <a href="new.html#table-users">users</a> site user</h3>`,
+		`<p>Logical name: <span class="old"></span> &rarr; site user</p>`,
+		`<td><span class="badge text-bg-success">Added</span></td>`,
+		`<td style="white-space: nowrap;">tag_id</td>`,
+		`<td style="white-space: nowrap;" class="table-warning"><span class="old">int</span><br/>bigint</td>`,
+		`<td style="white-space: nowrap;" class="table-warning"><span class="old">&#39;x&#39;</span><br/></td>`,
+	})
+}
+
+func TestChangelogNoChanges(t *testing.T) {
+	s := mustParse(t, testDiffOld)
+	b := &strings.Builder{}
+	if err := (&Changelog{Title: "t", Diff: Diff(s, s)}).Render(b); err != nil {
+		t.Fatal(err)
+	}
+	assertInOrder(t, b.String(), []string{"<p>No schema changes.</p>"})
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testCompositeHeader = "# Title: x\n\ntenant_users\n    +tenant_id [bigint][NN]\n    +user_id   [bigint][NN]\n    code       [text]\n\narticles\n    +id       [bigint][NN]\n    tenant_id [bigint]\n    user_id   [bigint]\n"
+
+func TestCompositeRelation(t *testing.T) {
+	s := mustParse(t, testCompositeHeader+"    Fk ( tenant_id ,user_id )  1..*--0..1   tenant_users ( tenant_id,user_id )\n")
+	r := s.Tables[1].CompositeRelations[0]
+	if r.GetColumns() != "tenant_id, user_id" || r.TableNameReal != "tenant_users" || r.GetReferenceColumns() != "tenant_id, user_id" {
+		t.Errorf("relation = %+v", r)
+	}
+	if r.CardinalitySource != "1..*" || r.CardinalityDestination != "0..1" {
+		t.Errorf("cardinality = %s--%s", r.CardinalitySource, r.CardinalityDestination)
+	}
+	if r.Position != (Position{Line: 12, Column: 5}) || r.TablePosition != (Position{Line: 12, Column: 45}) {
+		t.Errorf("positions = %+v %+v", r.Position, r.TablePosition)
+	}
+	// fk のカラムは外部キーとして扱う
+	if !s.Tables[1].Columns[1].IsForeignKey || !s.Tables[1].Columns[2].IsForeignKey || s.Tables[1].Columns[0].IsForeignKey {
+		t.Errorf("columns = %+v", s.Tables[1].Columns)
+	}
+	if got := r.GetErdmText(); got != "fk (tenant_id, user_id) 1..*--0..1 tenant_users (tenant_id, user_id)" {
+		t.Errorf("GetErdmText = %s", got)
+	}
+	fks := s.Tables[1].ForeignKeys
+	if len(fks) != 1 || fks[0].Name != "fk_articles_tenant_id_user_id" || strings.Join(fks[0].ReferenceColumns, ",") != "tenant_id,user_id" {
+		t.Errorf("foreign keys = %+v", fks)
+	}
+}
+
+func TestCompositeRelationDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		fk   string
+		want []string
+	}{
+		{"primary key", "    fk (tenant_id, user_id) 0..*--1 tenant_users", nil},
+		{"unknown column", "    fk (tenant_id, nope) 0..*--1 tenant_users", []string{"12:5: error: fk (tenant_id, nope): unknown column articles.nope"}},
+		{"unknown table", "    fk (tenant_id, user_id) 0..*--1 nope", []string{"12:37: error: fk (tenant_id, user_id) of articles references unknown table nope"}},
+		{"column count", "    fk (tenant_id) 0..*--1 tenant_users", []string{"12:28: error: fk (tenant_id) of articles has 1 columns but references 2 columns of tenant_users"}},
+		{"unknown referenced column", "    fk (tenant_id, user_id) 0..*--1 tenant_users (tenant_id, nope)", []string{"12:37: error: fk (tenant_id, user_id) of articles references unknown column tenant_users.nope"}},
+		{"not unique", "    fk (tenant_id, id) 0..*--1 tenant_users (tenant_id, code)", []string{
+			"12:5: warning: type of articles.id (bigint) differs from tenant_users.code (text)",
+			"12:32: warning: fk (tenant_id, id) of articles references tenant_users (tenant_id, code), which is neither the primary key nor unique; no FOREIGN KEY constraint is written",
+		}},
+		{"duplicate", "    fk (tenant_id, user_id) 0..*--1 tenant_users\n    fk (tenant_id, user_id) 0..*--1 tenant_users", []string{"13:5: error: relation of articles (tenant_id, user_id) is already defined at line 12"}},
+		{"missing ')'", "    fk (tenant_id, user_id 0..*--1 tenant_users", []string{`12:28: error: unexpected "0..*--1" (expected ',', ')')`}},
+		{"missing column", "    fk (tenant_id, ) 0..*--1 tenant_users", []string{`12:20: error: unexpected ")" (expected column name)`}},
+		{"missing '('", "    fk tenant_id 0..*--1 tenant_users", []string{`12:8: error: unexpected "tenant_id" (expected '(')`}},
+		{"missing relation", "    fk (tenant_id, user_id) tenant_users", []string{`12:29: error: unexpected "tenant_users" (expected relation)`}},
+		{"missing table", "    fk (tenant_id, user_id) 0..*--", []string{"12:35: error: unexpected end of line (expected table name)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ds := ParsePartial(strings.NewReader(testCompositeHeader + tt.fk + "\n"))
+			got := []string{}
+			for _, d := range ds {
+				got = append(got, d.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCompositeRelationOutputs(t *testing.T) {
+	s := mustParse(t, testCompositeHeader+"    fk (tenant_id, user_id) 0..*--0..1 tenant_users\n")
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"dot", []string{"    headlabel = \"0..*\"\n    taillabel = \"0..1\"\n  ]\n  articles -> tenant_users\n"}},
+		{"pg", []string{"    CONSTRAINT fk_articles_tenant_id_user_id FOREIGN KEY (tenant_id, user_id) REFERENCES tenant_users (tenant_id, user_id)\n);"}},
+		{"sqlite3", []string{"    CONSTRAINT fk_articles_tenant_id_user_id FOREIGN KEY (tenant_id, user_id) REFERENCES tenant_users (tenant_id, user_id)\n);"}},
+		{"mermaid", []string{`articles }o--o| tenant_users : "tenant_id, user_id"`}},
+		{"plantuml", []string{"articles }o--o| tenant_users : tenant_id, user_id"}},
+		{"dbml", []string{"Ref fk_articles_tenant_id_user_id: articles.(tenant_id, user_id) > tenant_users.(tenant_id, user_id)"}},
+		{"erdm", []string{"    user_id   [bigint]\n    fk (tenant_id, user_id) 0..*--0..1 tenant_users\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := mustRender(t, tt.format, s)
+			assertInOrder(t, out, tt.want)
+			// 1 つの fk は 1 本の線になる
+			if tt.format == "dot" && strings.Count(out, "->") != 1 {
+				t.Errorf("dot has %d edges:\n%s", strings.Count(out, "->"), out)
+			}
+		})
+	}
+}
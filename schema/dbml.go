@@ -0,0 +1,828 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DBML (dbdiagram.io) との相互変換。
+
+// dbmlString は DBML の文字列リテラルにする。改行を含む場合は三重引用符の複数行文字列にする。
+func dbmlString(s string) string {
+	if strings.Contains(s, "\n") {
+		return "'''" + strings.Replace(s, "'''", "\\'''", -1) + "'''"
+	}
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	return "'" + strings.Replace(s, "'", "\\'", -1) + "'"
+}
+
+// dbmlName は Project 名などを "..." で囲む。
+func dbmlName(s string) string {
+	return "\"" + strings.Replace(s, "\"", "\\\"", -1) + "\""
+}
+
+var dbmlNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\([0-9, ]*\))?$`)
+
+// dbmlType は空白などを含む型名を "..." で囲む。
+func dbmlType(t string) string {
+	if dbmlNamePattern.MatchString(t) {
+		return t
+	}
+	return "\"" + t + "\""
+}
+
+var dbmlLiteralPattern = regexp.MustCompile(`^(-?[0-9]+(\.[0-9]+)?|true|false|null)$`)
+
+var sqlStringPattern = regexp.MustCompile(`^'((?:[^']|'')*)'$`)
+
+// dbmlDefault は数値・真偽値・文字列以外のデフォルト値を `now()` のような式として書く。
+// SQL の文字列 'x''y' は DBML の文字列 'x\'y' に書き直す。
+func dbmlDefault(d string) string {
+	if dbmlLiteralPattern.MatchString(strings.ToLower(d)) {
+		return d
+	}
+	if m := sqlStringPattern.FindStringSubmatch(d); m != nil {
+		return dbmlString(strings.Replace(m[1], "''", "'", -1))
+	}
+	return "`" + d + "`"
+}
+
+func (c *Column) GetDBMLSettings() string {
+	ss := []string{}
+	if c.IsPrimaryKey {
+		ss = append(ss, "pk")
+	}
+	if !c.AllowNull {
+		ss = append(ss, "not null")
+	}
+	if c.IsUnique {
+		ss = append(ss, "unique")
+	}
+	if c.HasDefaultSetting() {
+		ss = append(ss, "default: "+dbmlDefault(c.Default))
+	}
+	if d := c.GetDescription(); len(d) > 0 {
+		// 論理名が空でもコメントの行がずれないよう、1 行目は常に論理名にする
+		if len(c.Title) == 0 {
+			d = "\n" + d
+		}
+		ss = append(ss, "note: "+dbmlString(d))
+	}
+	if len(ss) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(ss, ", ") + "]"
+}
+
+// GetDBMLNote はテーブルの Note を返す。列の note と同じく、1 行目は常に論理名にする。
+func (t *Table) GetDBMLNote() string {
+	d := t.GetDescription()
+	if len(t.Title) == 0 && len(d) > 0 {
+		d = "\n" + d
+	}
+	return d
+}
+
+// GetDBMLOperator は多重度を DBML の Ref の演算子（> < - <>）にする。
+func (f *ForeignKey) GetDBMLOperator() string {
+	src, dst := f.CardinalitySource, f.CardinalityDestination
+	if len(src) == 0 {
+		src = "0..*"
+	}
+	if len(dst) == 0 {
+		dst = "1"
+	}
+	_, srcMax := splitCardinality(src)
+	_, dstMax := splitCardinality(dst)
+	switch {
+	case srcMax == "*" && dstMax == "*":
+		return "<>"
+	case srcMax == "*":
+		return ">"
+	case dstMax == "*":
+		return "<"
+	}
+	return "-"
+}
+
+func dbmlColumns(cs []string) string {
+	if len(cs) == 1 {
+		return cs[0]
+	}
+	return "(" + strings.Join(cs, ", ") + ")"
+}
+
+type dbmlToken struct {
+	kind string // ident, string, expr, number, symbol, newline, eof
+	text string
+	line int
+}
+
+type dbmlReader struct {
+	tokens  []dbmlToken
+	pos     int
+	erd     *Schema
+	refs    []dbmlRef
+	aliases map[string]string
+}
+
+type dbmlRef struct {
+	table, column       string
+	refTable, refColumn string
+	op                  string
+	line                int
+	// columns / refColumns は複合 Ref（a.(x, y) > b.(p, q)）のカラム
+	columns, refColumns []string
+}
+
+func tokenizeDBML(src string) ([]dbmlToken, error) {
+	ts := []dbmlToken{}
+	rs := []rune(src)
+	line := 1
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case c == '\n':
+			ts = append(ts, dbmlToken{"newline", "\n", line})
+			line++
+			i++
+		case unicode.IsSpace(c):
+			i++
+		case c == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i += 2
+			for i < len(rs) && !(rs[i] == '*' && i+1 < len(rs) && rs[i+1] == '/') {
+				if rs[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i += 2
+		case c == '\'' && i+2 < len(rs) && rs[i+1] == '\'' && rs[i+2] == '\'':
+			start := line
+			j := i + 3
+			b := []rune{}
+			for ; j+2 < len(rs) && !(rs[j] == '\'' && rs[j+1] == '\'' && rs[j+2] == '\''); j++ {
+				if rs[j] == '\\' && rs[j+1] == '\'' {
+					j++
+				}
+				if rs[j] == '\n' {
+					line++
+				}
+				b = append(b, rs[j])
+			}
+			if j+2 >= len(rs) {
+				return nil, fmt.Errorf("line %d: unterminated string", start)
+			}
+			ts = append(ts, dbmlToken{"string", string(b), start})
+			i = j + 3
+		case c == '\'' || c == '"' || c == '`':
+			start := line
+			j := i + 1
+			b := []rune{}
+			for ; j < len(rs) && rs[j] != c; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+				}
+				if rs[j] == '\n' {
+					line++
+				}
+				b = append(b, rs[j])
+			}
+			if j >= len(rs) {
+				return nil, fmt.Errorf("line %d: unterminated string", start)
+			}
+			kind := map[rune]string{'\'': "string", '"': "ident", '`': "expr"}[c]
+			ts = append(ts, dbmlToken{kind, string(b), start})
+			i = j + 1
+		case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) || c == '#':
+			j := i
+			for j < len(rs) && (rs[j] == '_' || unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '#' || (rs[j] == '.' && unicode.IsDigit(c))) {
+				j++
+			}
+			kind := "ident"
+			if unicode.IsDigit(c) {
+				kind = "number"
+			}
+			ts = append(ts, dbmlToken{kind, string(rs[i:j]), line})
+			i = j
+		case c == '<' && i+1 < len(rs) && rs[i+1] == '>':
+			ts = append(ts, dbmlToken{"symbol", "<>", line})
+			i += 2
+		default:
+			ts = append(ts, dbmlToken{"symbol", string(c), line})
+			i++
+		}
+	}
+	ts = append(ts, dbmlToken{"eof", "", line})
+	return ts, nil
+}
+
+func (r *dbmlReader) peek() dbmlToken {
+	return r.tokens[r.pos]
+}
+
+func (r *dbmlReader) next() dbmlToken {
+	t := r.tokens[r.pos]
+	if t.kind != "eof" {
+		r.pos++
+	}
+	return t
+}
+
+func (r *dbmlReader) skipNewlines() {
+	for r.peek().kind == "newline" {
+		r.pos++
+	}
+}
+
+func (r *dbmlReader) is(text string) bool {
+	t := r.peek()
+	return (t.kind == "symbol" || t.kind == "ident") && strings.EqualFold(t.text, text)
+}
+
+func (r *dbmlReader) expect(text string) error {
+	r.skipNewlines()
+	t := r.next()
+	if !strings.EqualFold(t.text, text) || (t.kind != "symbol" && t.kind != "ident") {
+		return fmt.Errorf("line %d: expected %q but found %q", t.line, text, t.text)
+	}
+	return nil
+}
+
+// skipBlock は { から対応する } までを読み飛ばす。
+func (r *dbmlReader) skipBlock() error {
+	if err := r.expect("{"); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		t := r.next()
+		switch {
+		case t.kind == "eof":
+			return fmt.Errorf("line %d: unterminated block", t.line)
+		case t.kind == "symbol" && t.text == "{":
+			depth++
+		case t.kind == "symbol" && t.text == "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+// readName は schema.table のような名前を読み、最後の要素を返す。
+func (r *dbmlReader) readName() (string, error) {
+	r.skipNewlines()
+	t := r.next()
+	if t.kind != "ident" && t.kind != "number" {
+		return "", fmt.Errorf("line %d: expected a name but found %q", t.line, t.text)
+	}
+	name := t.text
+	for r.is(".") && (r.tokens[r.pos+1].kind == "ident") {
+		r.next()
+		name = r.next().text
+	}
+	return name, nil
+}
+
+// readSettings は [pk, not null, default: 1] のような設定を読み、キーと値の組にする。
+func (r *dbmlReader) readSettings() ([][2]string, error) {
+	ss := [][2]string{}
+	if !r.is("[") {
+		return ss, nil
+	}
+	r.next()
+	for {
+		r.skipNewlines()
+		if r.is("]") {
+			r.next()
+			return ss, nil
+		}
+		key := []string{}
+		for r.peek().kind == "ident" {
+			key = append(key, strings.ToLower(r.next().text))
+		}
+		value := ""
+		if r.is(":") {
+			t := r.next()
+			if len(key) == 0 {
+				return nil, fmt.Errorf("line %d: setting name expected", t.line)
+			}
+			parts := []string{}
+			for !r.is(",") && !r.is("]") {
+				t := r.next()
+				switch t.kind {
+				case "eof", "newline":
+					return nil, fmt.Errorf("line %d: unterminated settings", t.line)
+				case "string":
+					if len(parts) == 0 && (key[0] == "note" || key[0] == "name") {
+						parts = append(parts, t.text)
+					} else {
+						parts = append(parts, "'"+strings.Replace(t.text, "'", "''", -1)+"'")
+					}
+				case "number":
+					// -1 は記号の - と数値の 1 に分かれるので、符号は数値につなげる
+					if n := len(parts); n > 0 && (parts[n-1] == "-" || parts[n-1] == "+") {
+						parts[n-1] += t.text
+					} else {
+						parts = append(parts, t.text)
+					}
+				default:
+					parts = append(parts, t.text)
+				}
+			}
+			value = strings.Join(parts, " ")
+			if key[0] == "ref" {
+				value = strings.Join(parts, "")
+			}
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("line %d: invalid setting %q", r.peek().line, r.peek().text)
+		}
+		ss = append(ss, [2]string{strings.Join(key, " "), value})
+		if r.is(",") {
+			r.next()
+		}
+	}
+}
+
+// ParseDBML は DBML を読み込む。結果は Parse と同じく、エラーがあれば Schema は nil になる。
+func ParseDBML(r io.Reader) (*Schema, []Diagnostic) {
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+	e, err := readDBML(string(bs))
+	if err != nil {
+		return nil, []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+	return e.build()
+}
+
+// readDBML は DBML を Schema に読み込む。
+func readDBML(src string) (*Schema, error) {
+	ts, err := tokenizeDBML(src)
+	if err != nil {
+		return nil, err
+	}
+	r := &dbmlReader{tokens: ts, erd: &Schema{}, aliases: map[string]string{}}
+	for {
+		r.skipNewlines()
+		t := r.peek()
+		if t.kind == "eof" {
+			break
+		}
+		switch {
+		case r.is("Project"):
+			r.next()
+			name, err := r.readName()
+			if err != nil {
+				return nil, err
+			}
+			r.erd.setTitle(name)
+			if err = r.skipBlock(); err != nil {
+				return nil, err
+			}
+		case r.is("Table"):
+			if err = r.readTable(); err != nil {
+				return nil, err
+			}
+		case r.is("Ref"):
+			if err = r.readRefs(); err != nil {
+				return nil, err
+			}
+		case r.is("Enum") || r.is("TableGroup") || r.is("Note") || r.is("TablePartial"):
+			r.next()
+			for !r.is("{") && r.peek().kind != "eof" {
+				r.next()
+			}
+			if err = r.skipBlock(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", t.line, t.text)
+		}
+	}
+	if err = r.applyRefs(); err != nil {
+		return nil, err
+	}
+	return r.erd, nil
+}
+
+// setTableNote はテーブルの Note の 1 行目を論理名、2 行目以降を説明にする。
+func (r *dbmlReader) setTableNote(note string) {
+	lines := strings.Split(note, "\n")
+	r.erd.addTableTitle(lines[0])
+	for _, l := range lines[1:] {
+		r.erd.addTableComment(l)
+	}
+}
+
+func (r *dbmlReader) readTable() error {
+	r.next()
+	name, err := r.readName()
+	if err != nil {
+		return err
+	}
+	e := r.erd
+	e.addTableTitleReal(name)
+	if r.is("as") {
+		r.next()
+		r.aliases[r.next().text] = name
+	}
+	settings, err := r.readSettings()
+	if err != nil {
+		return err
+	}
+	for _, s := range settings {
+		if s[0] == "note" {
+			r.setTableNote(s[1])
+		}
+	}
+	if err = r.expect("{"); err != nil {
+		return err
+	}
+	for {
+		r.skipNewlines()
+		t := r.peek()
+		switch {
+		case t.kind == "eof":
+			return fmt.Errorf("line %d: unterminated table %s", t.line, name)
+		case r.is("}"):
+			r.next()
+			return nil
+		case r.is("Note") && r.tokens[r.pos+1].text == ":":
+			r.next()
+			r.next()
+			r.setTableNote(r.next().text)
+		case r.is("Note") && r.tokens[r.pos+1].text == "{":
+			r.next()
+			r.next()
+			r.skipNewlines()
+			r.setTableNote(r.next().text)
+			if err = r.expect("}"); err != nil {
+				return err
+			}
+		case r.is("indexes"):
+			r.next()
+			if err = r.readIndexes(); err != nil {
+				return err
+			}
+		default:
+			if err = r.readColumn(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *dbmlReader) readColumn() error {
+	e := r.erd
+	t := r.next()
+	if t.kind != "ident" {
+		return fmt.Errorf("line %d: expected a column name but found %q", t.line, t.text)
+	}
+	name := t.text
+	typ := ""
+	for !r.is("[") && r.peek().kind != "newline" && !r.is("}") && r.peek().kind != "eof" {
+		tt := r.next()
+		if tt.kind == "symbol" && (tt.text == "(" || tt.text == ")" || tt.text == ",") {
+			typ = strings.TrimSpace(typ) + tt.text
+			continue
+		}
+		if strings.HasSuffix(typ, "(") || strings.HasSuffix(typ, ",") || len(typ) == 0 {
+			typ += tt.text
+		} else {
+			typ += " " + tt.text
+		}
+	}
+	settings, err := r.readSettings()
+	if err != nil {
+		return err
+	}
+	table := &e.Tables[e.currentTableId]
+	for _, s := range settings {
+		if s[0] == "pk" || s[0] == "primary key" {
+			e.addPrimaryKey("+")
+		}
+	}
+	e.setColumnNameReal(name)
+	if len(typ) == 0 {
+		return fmt.Errorf("line %d: column %s.%s has no type", t.line, table.TitleReal, name)
+	}
+	for _, s := range settings {
+		switch s[0] {
+		case "increment":
+			switch normalizeType(typ) {
+			case "bigint", "int8":
+				typ = "bigserial"
+			case "int", "integer", "int4":
+				typ = "serial"
+			}
+		}
+	}
+	e.addColumnType(typ)
+	for _, s := range settings {
+		switch s[0] {
+		case "not null":
+			e.setNotNull()
+		case "unique":
+			e.setUnique()
+		case "default":
+			e.setColumnDefault(strings.Trim(s[1], "`"))
+		case "note":
+			lines := strings.Split(s[1], "\n")
+			if len(lines[0]) > 0 {
+				e.setColumnName(lines[0])
+			}
+			for _, l := range lines[1:] {
+				e.addComment(l)
+			}
+		case "ref":
+			ref, err := parseInlineRef(s[1], t.line)
+			if err != nil {
+				return err
+			}
+			ref.table, ref.column = table.TitleReal, name
+			r.refs = append(r.refs, ref)
+		}
+	}
+	return nil
+}
+
+var dbmlInlineRefPattern = regexp.MustCompile(`^(<>|<|>|-)(?:[A-Za-z0-9_]+\.)?([A-Za-z0-9_]+)\.([A-Za-z0-9_]+)$`)
+
+func parseInlineRef(s string, line int) (dbmlRef, error) {
+	m := dbmlInlineRefPattern.FindStringSubmatch(s)
+	if m == nil {
+		return dbmlRef{}, fmt.Errorf("line %d: unsupported ref %q", line, s)
+	}
+	return dbmlRef{op: m[1], refTable: m[2], refColumn: m[3], line: line}, nil
+}
+
+func (r *dbmlReader) readIndexes() error {
+	e := r.erd
+	if err := r.expect("{"); err != nil {
+		return err
+	}
+	for {
+		r.skipNewlines()
+		if r.is("}") {
+			r.next()
+			return nil
+		}
+		t := r.peek()
+		columns := []string{}
+		if r.is("(") {
+			r.next()
+			for !r.is(")") {
+				tt := r.next()
+				switch {
+				case tt.kind == "eof" || tt.kind == "newline":
+					return fmt.Errorf("line %d: unterminated index", tt.line)
+				case tt.kind == "expr":
+					return fmt.Errorf("line %d: expression index `%s` is not supported", tt.line, tt.text)
+				case tt.kind == "ident":
+					columns = append(columns, tt.text)
+				}
+			}
+			r.next()
+		} else if t.kind == "ident" {
+			columns = append(columns, r.next().text)
+		} else if t.kind == "expr" {
+			return fmt.Errorf("line %d: expression index `%s` is not supported", t.line, t.text)
+		} else {
+			return fmt.Errorf("line %d: unsupported index %q", t.line, t.text)
+		}
+		settings, err := r.readSettings()
+		if err != nil {
+			return err
+		}
+		table := &e.Tables[e.currentTableId]
+		name, unique, pk := "", false, false
+		for _, s := range settings {
+			switch s[0] {
+			case "name":
+				name = s[1]
+			case "unique":
+				unique = true
+			case "pk":
+				pk = true
+			}
+		}
+		if pk {
+			for _, c := range columns {
+				i, err := table.getColumnIndex(c)
+				if err != nil {
+					return fmt.Errorf("line %d: unknown column %s.%s", t.line, table.TitleReal, c)
+				}
+				table.addPrimaryKeyColumn(i)
+			}
+			continue
+		}
+		if len(name) == 0 {
+			name = "i_" + table.TitleReal + "_" + strings.Join(columns, "_")
+		}
+		e.setIndexName(name)
+		for _, c := range columns {
+			if _, err := table.getColumnIndex(c); err != nil {
+				return fmt.Errorf("line %d: unknown column %s.%s", t.line, table.TitleReal, c)
+			}
+			e.setIndexColumn(c)
+		}
+		if unique {
+			e.setUniqueIndex()
+		}
+	}
+}
+
+// readRefs は "Ref: a.b > c.d" と "Ref name { a.b > c.d ... }" の両方を読む。
+func (r *dbmlReader) readRefs() error {
+	r.next()
+	for !r.is(":") && !r.is("{") {
+		if r.peek().kind == "eof" {
+			return fmt.Errorf("line %d: invalid Ref", r.peek().line)
+		}
+		r.next()
+	}
+	if r.is(":") {
+		r.next()
+		return r.readRef()
+	}
+	r.next()
+	for {
+		r.skipNewlines()
+		if r.is("}") {
+			r.next()
+			return nil
+		}
+		if err := r.readRef(); err != nil {
+			return err
+		}
+	}
+}
+
+// readEndpoint は Ref の片側（table.column か table.(a, b)）を読み、テーブルとカラムを返す。
+func (r *dbmlReader) readEndpoint() (string, []string, error) {
+	parts := []string{}
+	line := r.peek().line
+	for {
+		t := r.next()
+		if t.kind == "symbol" && t.text == "(" && len(parts) > 0 {
+			columns, err := r.readEndpointColumns()
+			return parts[len(parts)-1], columns, err
+		}
+		if t.kind != "ident" {
+			return "", nil, fmt.Errorf("line %d: expected a column in Ref but found %q", t.line, t.text)
+		}
+		parts = append(parts, t.text)
+		if !r.is(".") {
+			break
+		}
+		r.next()
+	}
+	if len(parts) < 2 {
+		return "", nil, fmt.Errorf("line %d: Ref endpoint must be table.column", line)
+	}
+	return parts[len(parts)-2], parts[len(parts)-1:], nil
+}
+
+// readEndpointColumns は複合 Ref の "(" の後ろから ")" までのカラムを読む。
+func (r *dbmlReader) readEndpointColumns() ([]string, error) {
+	columns := []string{}
+	for {
+		t := r.next()
+		if t.kind != "ident" {
+			return nil, fmt.Errorf("line %d: expected a column in Ref but found %q", t.line, t.text)
+		}
+		columns = append(columns, t.text)
+		t = r.next()
+		if t.text == ")" {
+			return columns, nil
+		}
+		if t.text != "," {
+			return nil, fmt.Errorf("line %d: expected ',' or ')' in Ref but found %q", t.line, t.text)
+		}
+	}
+}
+
+func (r *dbmlReader) readRef() error {
+	r.skipNewlines()
+	line := r.peek().line
+	table, columns, err := r.readEndpoint()
+	if err != nil {
+		return err
+	}
+	op := r.next()
+	if op.kind != "symbol" || !in_array(op.text, []string{">", "<", "-", "<>"}) {
+		return fmt.Errorf("line %d: unknown Ref operator %q", op.line, op.text)
+	}
+	refTable, refColumns, err := r.readEndpoint()
+	if err != nil {
+		return err
+	}
+	if len(columns) != len(refColumns) {
+		return fmt.Errorf("line %d: Ref has %d columns on the left and %d on the right", line, len(columns), len(refColumns))
+	}
+	if _, err = r.readSettings(); err != nil {
+		return err
+	}
+	ref := dbmlRef{table: table, column: columns[0], refTable: refTable, refColumn: refColumns[0], op: op.text, line: line}
+	if len(columns) > 1 {
+		ref.columns, ref.refColumns = columns, refColumns
+	}
+	r.refs = append(r.refs, ref)
+	return nil
+}
+
+// applyRefs は Ref を参照元カラムのリレーションにする。< は向きを入れ替えて > として扱う。
+func (r *dbmlReader) applyRefs() error {
+	e := r.erd
+	for _, ref := range r.refs {
+		if n, ok := r.aliases[ref.table]; ok {
+			ref.table = n
+		}
+		if n, ok := r.aliases[ref.refTable]; ok {
+			ref.refTable = n
+		}
+		if ref.op == "<" {
+			ref.table, ref.column, ref.refTable, ref.refColumn = ref.refTable, ref.refColumn, ref.table, ref.column
+			ref.columns, ref.refColumns = ref.refColumns, ref.columns
+			ref.op = ">"
+		}
+		ti := e.getTableIndex(ref.table)
+		ri := e.getTableIndex(ref.refTable)
+		if ti < 0 || ri < 0 {
+			return fmt.Errorf("line %d: unknown table in Ref %s.%s - %s.%s", ref.line, ref.table, ref.column, ref.refTable, ref.refColumn)
+		}
+		if len(ref.columns) > 0 {
+			if err := r.applyCompositeRef(ref, ti, ri); err != nil {
+				return err
+			}
+			continue
+		}
+		ci, err := e.Tables[ti].getColumnIndex(ref.column)
+		if err != nil {
+			return fmt.Errorf("line %d: unknown column %s.%s", ref.line, ref.table, ref.column)
+		}
+		e.currentTableId = ti
+		e.currentColumnId = ci
+		switch ref.op {
+		case ">":
+			e.setRelationSource("0..*")
+			e.setRelationDestination("1")
+		case "-":
+			e.setRelationSource("0..1")
+			e.setRelationDestination("1")
+		case "<>":
+			e.setRelationSource("0..*")
+			e.setRelationDestination("0..*")
+		}
+		target := ref.refTable
+		pks := e.Tables[ri].GetPrimaryKeyColumnNames()
+		if len(pks) != 1 || pks[0] != ref.refColumn {
+			target += "." + ref.refColumn
+		}
+		e.setRelationTableNameReal(target)
+	}
+	return nil
+}
+
+// applyCompositeRef は複合 Ref を参照元テーブルの fk (...) にする。
+func (r *dbmlReader) applyCompositeRef(ref dbmlRef, ti, ri int) error {
+	e := r.erd
+	for _, c := range ref.columns {
+		if _, err := e.Tables[ti].getColumnIndex(c); err != nil {
+			return fmt.Errorf("line %d: unknown column %s.%s", ref.line, ref.table, c)
+		}
+	}
+	e.currentTableId = ti
+	e.addCompositeRelation()
+	for _, c := range ref.columns {
+		e.addCompositeRelationColumn(c)
+	}
+	switch ref.op {
+	case ">":
+		e.setCompositeRelationSource("0..*")
+		e.setCompositeRelationDestination("1")
+	case "-":
+		e.setCompositeRelationSource("0..1")
+		e.setCompositeRelationDestination("1")
+	case "<>":
+		e.setCompositeRelationSource("0..*")
+		e.setCompositeRelationDestination("0..*")
+	}
+	e.setCompositeRelationTableNameReal(ref.refTable)
+	if !sameStrings(ref.refColumns, e.Tables[ri].GetPrimaryKeyColumnNames()) {
+		for _, c := range ref.refColumns {
+			e.addCompositeRelationReferenceColumn(c)
+		}
+	}
+	return nil
+}
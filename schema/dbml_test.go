@@ -0,0 +1,185 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDBMLSource = `Project shop {
+  database_type: 'PostgreSQL'
+  Note: 'shop schema'
+}
+
+Enum status {
+  active
+  closed
+}
+
+Table users as U {
+  id bigserial [pk, increment]
+  email varchar(255) [not null, unique, note: 'login']
+  created timestamp [default: ` + "`now()`" + `]
+  Note: '''site user
+registered members'''
+}
+
+Table articles {
+  id bigserial [pk]
+  owner_id bigint [not null, ref: > U.id]
+  title text [default: 'untitled']
+
+  indexes {
+    owner_id [name: 'i_articles_owner']
+    (owner_id, title) [unique, name: 'i_articles_owner_title']
+  }
+}
+
+Table tenants_users {
+  tenant_id bigint [pk]
+  user_id bigint [pk]
+}
+
+Table memberships {
+  id bigserial [pk]
+  tenant_id bigint
+  user_id bigint
+}
+
+Ref: memberships.(tenant_id, user_id) > tenants_users.(tenant_id, user_id)
+
+TableGroup g {
+  users
+}
+`
+
+func TestParseDBML(t *testing.T) {
+	s, ds := ParseDBML(strings.NewReader(testDBMLSource))
+	if s == nil {
+		t.Fatalf("ParseDBML failed: %v", ds)
+	}
+	users := s.Tables[s.getTableIndex("users")]
+	if users.Title != "site user" || strings.Join(users.Comments, "|") != "registered members" {
+		t.Errorf("users note = %q %q", users.Title, users.Comments)
+	}
+	email := users.Columns[1]
+	if email.AllowNull || !email.IsUnique || email.Title != "login" {
+		t.Errorf("email = %+v", email)
+	}
+	if users.Columns[2].Default != "now()" {
+		t.Errorf("default = %q, want now()", users.Columns[2].Default)
+	}
+	articles := s.Tables[s.getTableIndex("articles")]
+	if r := articles.Columns[1].Relation; r.TableNameReal != "users" || r.CardinalitySource != "0..*" || r.CardinalityDestination != "1" {
+		t.Errorf("owner_id relation = %+v", r)
+	}
+	if len(articles.Indexes) != 2 || !articles.Indexes[1].IsUnique || articles.Indexes[1].GetIndexColumns() != "owner_id, title" {
+		t.Errorf("indexes = %+v", articles.Indexes)
+	}
+	memberships := s.Tables[s.getTableIndex("memberships")]
+	if len(memberships.CompositeRelations) != 1 {
+		t.Fatalf("composite relations = %+v", memberships.CompositeRelations)
+	}
+	if r := memberships.CompositeRelations[0]; r.GetErdmText() != "fk (tenant_id, user_id) 0..*--1 tenants_users" {
+		t.Errorf("composite relation = %q", r.GetErdmText())
+	}
+}
+
+func TestParseDBMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"setting without name", "Table t {\n  id int [: 'x']\n}\n", "line 2: setting name expected"},
+		{"unterminated settings", "Table t {\n  id int [pk\n}\n", "line 3: invalid setting"},
+		{"unterminated value", "Table t {\n  id int [note: 'x'\n]\n}\n", "line 2: unterminated settings"},
+		{"unknown table in ref", "Table t {\n  id int [pk]\n}\nRef: t.id > u.id\n", "unknown table"},
+		{"unknown column in ref", "Table t {\n  id int [pk]\n}\nRef: t.x > t.id\n", "unknown column t.x"},
+		{"ref column count", "Table t {\n  a int\n  b int\n}\nRef: t.(a, b) > t.a\n", "2 columns on the left and 1 on the right"},
+		{"ref operator", "Table t {\n  id int [pk]\n}\nRef: t.id = t.id\n", "unknown Ref operator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ds := ParseDBML(strings.NewReader(tt.src))
+			if s != nil || !Diagnostics(ds).HasError() {
+				t.Fatalf("ParseDBML(%q) succeeded", tt.src)
+			}
+			if !strings.Contains(ds[0].Message, tt.want) {
+				t.Errorf("error = %q, want %q", ds[0].Message, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseDBMLDoesNotPanic は壊れた DBML でも panic せずにエラーを返すことを確かめる。
+func TestParseDBMLDoesNotPanic(t *testing.T) {
+	srcs := []string{}
+	rs := []rune(testDBMLSource)
+	for i := range rs {
+		srcs = append(srcs, string(rs[:i]), string(rs[:i])+string(rs[i+1:]))
+	}
+	for _, src := range srcs {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("ParseDBML panicked: %v\n%s", r, src)
+				}
+			}()
+			ParseDBML(strings.NewReader(src))
+		}()
+	}
+}
+
+func TestDBMLRoundTrip(t *testing.T) {
+	s, ds := Parse(strings.NewReader(testErdMSource))
+	if s == nil {
+		t.Fatalf("Parse failed: %v", ds)
+	}
+	b := &strings.Builder{}
+	if err := Render("dbml", s, b); err != nil {
+		t.Fatal(err)
+	}
+	d, ds := ParseDBML(strings.NewReader(b.String()))
+	if d == nil {
+		t.Fatalf("ParseDBML failed: %v\n%s", ds, b.String())
+	}
+	want, got := &strings.Builder{}, &strings.Builder{}
+	Render("pg", s, want)
+	Render("pg", d, got)
+	if want.String() != got.String() {
+		t.Errorf("pg DDL differs after the DBML round trip:\n%s\n---\n%s", want.String(), got.String())
+	}
+}
+
+func TestDBMLRoundTripDefaults(t *testing.T) {
+	s, ds := Parse(strings.NewReader("# Title: t\n\nt\n    +id [int][NN]\n    a [text][='x''y']\n    b [int][=-1]\n    c [numeric][=+1.5]\n    d [text][='a\\b']\n    e [timestamp][=now()]\n    f [bool][=true]\n"))
+	if s == nil {
+		t.Fatalf("Parse failed: %v", ds)
+	}
+	b := &strings.Builder{}
+	if err := Render("dbml", s, b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`a text [default: 'x\'y']`, `b int [default: -1]`, "c numeric [default: `+1.5`]", `d text [default: 'a\\b']`, "e timestamp [default: `now()`]", "f bool [default: true]"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("%q is missing in\n%s", want, b.String())
+		}
+	}
+	d, ds := ParseDBML(strings.NewReader(b.String()))
+	if d == nil {
+		t.Fatalf("ParseDBML failed: %v\n%s", ds, b.String())
+	}
+	for i, want := range []string{"", "'x''y'", "-1", "+1.5", `'a\b'`, "now()", "true"} {
+		if got := d.Tables[0].Columns[i].Default; got != want {
+			t.Errorf("default of %s = %q, want %q", d.Tables[0].Columns[i].TitleReal, got, want)
+		}
+	}
+	// DBML の数値の符号は記号として読まれる
+	e, ds := ParseDBML(strings.NewReader("Table t {\n  a int [default: -1]\n  b int [default: + 2]\n}\n"))
+	if e == nil {
+		t.Fatalf("ParseDBML failed: %v", ds)
+	}
+	if a, b := e.Tables[0].Columns[0].Default, e.Tables[0].Columns[1].Default; a != "-1" || b != "+2" {
+		t.Errorf("defaults = %q, %q, want -1, +2", a, b)
+	}
+}
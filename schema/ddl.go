@@ -0,0 +1,200 @@
+package schema
+
+import (
+	"strings"
+	"text/template"
+)
+
+type ForeignKey struct {
+	Name                   string
+	Columns                []string
+	TableNameReal          string
+	ReferenceColumns       []string
+	CardinalitySource      string
+	CardinalityDestination string
+	// IsCyclic は循環参照のため CREATE TABLE 内に書けず、ALTER TABLE で後付けする制約
+	IsCyclic bool
+}
+
+func (f *ForeignKey) GetColumns() string {
+	return strings.Join(f.Columns, ", ")
+}
+
+func (f *ForeignKey) GetReferenceColumns() string {
+	return strings.Join(f.ReferenceColumns, ", ")
+}
+
+func (e *Schema) getTableIndex(s string) int {
+	for i, t := range e.Tables {
+		if t.TitleReal == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildForeignKeys は各カラムの Relation と fk (...) から FOREIGN KEY 制約を組み立て、
+// 参照先テーブルが先に作られるよう CREATE TABLE の順序を決める。
+// 参照先が主キーでも一意でもないリレーションは、データベースが受け付けないので制約にしない。
+func (e *Schema) buildForeignKeys() {
+	for ti := range e.Tables {
+		t := &e.Tables[ti]
+		t.ForeignKeys = nil
+		for _, c := range t.Columns {
+			if !c.HasRelation() {
+				continue
+			}
+			ri := e.getTableIndex(c.Relation.TableNameReal)
+			if ri < 0 {
+				continue
+			}
+			var refColumns []string
+			if len(c.Relation.ColumnNameReal) > 0 {
+				// 主キーでも一意でもないカラムへの参照は制約にできない
+				if i, err := e.Tables[ri].getColumnIndex(c.Relation.ColumnNameReal); err != nil || !e.Tables[ri].isUniqueColumn(i) {
+					continue
+				}
+				refColumns = []string{c.Relation.ColumnNameReal}
+			} else if len(e.Tables[ri].PrimaryKeys) == 1 {
+				refColumns = []string{e.Tables[ri].Columns[e.Tables[ri].PrimaryKeys[0]].TitleReal}
+			} else {
+				// 複合主キーへの単一カラム参照は制約にできない
+				continue
+			}
+			t.ForeignKeys = append(t.ForeignKeys, ForeignKey{
+				Name:                   "fk_" + t.TitleReal + "_" + c.TitleReal,
+				Columns:                []string{c.TitleReal},
+				TableNameReal:          e.Tables[ri].TitleReal,
+				ReferenceColumns:       refColumns,
+				CardinalitySource:      c.Relation.CardinalitySource,
+				CardinalityDestination: c.Relation.CardinalityDestination,
+			})
+		}
+		for _, r := range t.CompositeRelations {
+			ri := e.getTableIndex(r.TableNameReal)
+			if ri < 0 {
+				continue
+			}
+			refColumns := r.ReferenceColumns
+			if len(refColumns) == 0 {
+				refColumns = e.Tables[ri].GetPrimaryKeyColumnNames()
+			}
+			if len(refColumns) != len(r.Columns) || !e.Tables[ri].isUniqueColumns(refColumns) {
+				continue
+			}
+			t.ForeignKeys = append(t.ForeignKeys, ForeignKey{
+				Name:                   "fk_" + t.TitleReal + "_" + strings.Join(r.Columns, "_"),
+				Columns:                r.Columns,
+				TableNameReal:          e.Tables[ri].TitleReal,
+				ReferenceColumns:       refColumns,
+				CardinalitySource:      r.CardinalitySource,
+				CardinalityDestination: r.CardinalityDestination,
+			})
+		}
+	}
+	e.sortTables()
+}
+
+func (e *Schema) isCreatable(index int, created []bool) bool {
+	for _, fk := range e.Tables[index].ForeignKeys {
+		ri := e.getTableIndex(fk.TableNameReal)
+		if fk.IsCyclic || ri == index || created[ri] {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func (e *Schema) sortTables() {
+	created := make([]bool, len(e.Tables))
+	e.createOrder = []int{}
+	for len(e.createOrder) < len(e.Tables) {
+		progress := false
+		for i := range e.Tables {
+			if created[i] || !e.isCreatable(i, created) {
+				continue
+			}
+			created[i] = true
+			e.createOrder = append(e.createOrder, i)
+			progress = true
+		}
+		if progress {
+			continue
+		}
+		// 循環している: 残りの先頭テーブルを先に作り、未作成テーブルへの制約は後付けにする
+		for i := range e.Tables {
+			if created[i] {
+				continue
+			}
+			for fi := range e.Tables[i].ForeignKeys {
+				ri := e.getTableIndex(e.Tables[i].ForeignKeys[fi].TableNameReal)
+				if ri != i && !created[ri] {
+					e.Tables[i].ForeignKeys[fi].IsCyclic = true
+				}
+			}
+			created[i] = true
+			e.createOrder = append(e.createOrder, i)
+			break
+		}
+	}
+}
+
+// GetSortedTables は参照先テーブルが先に来る CREATE TABLE 順のテーブル一覧を返す。
+func (e *Schema) GetSortedTables() []Table {
+	if len(e.createOrder) != len(e.Tables) {
+		e.buildForeignKeys()
+	}
+	ts := []Table{}
+	for _, i := range e.createOrder {
+		ts = append(ts, e.Tables[i])
+	}
+	return ts
+}
+
+// GetReverseSortedTables は DROP TABLE 用に GetSortedTables の逆順を返す。
+func (e *Schema) GetReverseSortedTables() []Table {
+	ts := e.GetSortedTables()
+	for i, j := 0, len(ts)-1; i < j; i, j = i+1, j-1 {
+		ts[i], ts[j] = ts[j], ts[i]
+	}
+	return ts
+}
+
+var templateFuncs = template.FuncMap{
+	"quote":       quoteString,
+	"mysqlQuote":  quoteMySQLString,
+	"identifiers": quoteIdentifiers,
+	"mermaidType": mermaidType,
+	"quoteLabel":  quoteLabel,
+	"dbmlName":    dbmlName,
+	"dbmlString":  dbmlString,
+	"dbmlType":    dbmlType,
+	"dbmlColumns": dbmlColumns,
+	"join":        strings.Join,
+}
+
+// quoteString は SQL 標準の文字列リテラルにする（' を二重化）。
+func quoteString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// quoteMySQLString は MySQL の文字列リテラルにする。バックスラッシュもエスケープが必要。
+func quoteMySQLString(s string) string {
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	return quoteString(s)
+}
+
+// quoteIdentifiers は識別子のリストをクォートしてカンマ区切りにする。
+// q は "`" のように 1 文字か、"[]" のように開き・閉じの 2 文字で指定する。
+func quoteIdentifiers(q string, names []string) string {
+	l, r := q, q
+	if len(q) == 2 {
+		l, r = q[:1], q[1:]
+	}
+	qs := []string{}
+	for _, n := range names {
+		qs = append(qs, l+n+r)
+	}
+	return strings.Join(qs, ", ")
+}
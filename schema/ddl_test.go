@@ -0,0 +1,216 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+// testCyclicSource は employees と departments が互いを参照し、departments が自分自身も参照する。
+const testCyclicSource = `# Title: cyclic
+
+employees
+    +id [bigint][NN]
+    department_id [bigint] 0..*--1 departments
+
+departments
+    +id [bigint][NN]
+    manager_id [bigint] 0..1--1 employees
+    parent_id [bigint] 0..*--0..1 departments
+`
+
+func tableNames(ts []Table) string {
+	ns := []string{}
+	for _, t := range ts {
+		ns = append(ns, t.TitleReal)
+	}
+	return strings.Join(ns, " ")
+}
+
+func TestSortedTables(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"already sorted", testErdMSource, "users tenants tenant_users articles"},
+		{"child first", "# Title: t\n\narticles\n    +id [bigint][NN]\n    user_id [bigint] 0..*--1 users\n\nusers\n    +id [bigint][NN]\n", "users articles"},
+		{"chain", "# Title: t\n\nc\n    +id [int][NN]\n    b_id [int] 0..*--1 b\n\nb\n    +id [int][NN]\n    a_id [int] 0..*--1 a\n\na\n    +id [int][NN]\n", "a b c"},
+		{"cycle", testCyclicSource, "employees departments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustParse(t, tt.src)
+			if got := tableNames(s.GetSortedTables()); got != tt.want {
+				t.Errorf("GetSortedTables = %s, want %s", got, tt.want)
+			}
+			want := strings.Fields(tt.want)
+			for i, j := 0, len(want)-1; i < j; i, j = i+1, j-1 {
+				want[i], want[j] = want[j], want[i]
+			}
+			if got := tableNames(s.GetReverseSortedTables()); got != strings.Join(want, " ") {
+				t.Errorf("GetReverseSortedTables = %s, want %s", got, strings.Join(want, " "))
+			}
+		})
+	}
+}
+
+func TestForeignKeyConstraints(t *testing.T) {
+	tests := []struct {
+		format string
+		src    string
+		want   []string
+	}{
+		{"pg", testErdMSource, []string{
+			"DROP TABLE IF EXISTS articles CASCADE;",
+			"DROP TABLE IF EXISTS users CASCADE;",
+			"CREATE TABLE users (",
+			"CREATE TABLE tenant_users (",
+			"    CONSTRAINT fk_tenant_users_tenant_id FOREIGN KEY (tenant_id) REFERENCES tenants (id),\n",
+			"    CONSTRAINT fk_tenant_users_user_id FOREIGN KEY (user_id) REFERENCES users (id)\n);",
+			"CREATE TABLE articles (",
+			"    CONSTRAINT fk_articles_owner_user_id FOREIGN KEY (owner_user_id) REFERENCES users (id),\n",
+		}},
+		{"pg", testCyclicSource, []string{
+			"CREATE TABLE employees (\n    id bigint NOT NULL,\n    department_id bigint,\n\n    PRIMARY KEY (id)\n);",
+			"    CONSTRAINT fk_departments_manager_id FOREIGN KEY (manager_id) REFERENCES employees (id),\n",
+			"    CONSTRAINT fk_departments_parent_id FOREIGN KEY (parent_id) REFERENCES departments (id)\n);",
+			"ALTER TABLE employees ADD CONSTRAINT fk_employees_department_id FOREIGN KEY (department_id) REFERENCES departments (id);",
+		}},
+		{"sqlite3", testCyclicSource, []string{
+			"DROP TABLE IF EXISTS departments;",
+			"CREATE TABLE employees (",
+			"    CONSTRAINT fk_employees_department_id FOREIGN KEY (department_id) REFERENCES departments (id)\n);",
+			"CREATE TABLE departments (",
+			"    CONSTRAINT fk_departments_manager_id FOREIGN KEY (manager_id) REFERENCES employees (id),\n",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assertInOrder(t, mustRender(t, tt.format, mustParse(t, tt.src)), tt.want)
+		})
+	}
+}
+
+func TestNoForeignKeyToNonUniqueColumn(t *testing.T) {
+	src := "# Title: t\n\nusers\n    +id [int][NN]\n    code [text]\n    mail [text][U]\n\narticles\n    +id [int][NN]\n    user_code [text] 0..*--1 users.code\n    user_mail [text] 0..*--1 users.mail\n    fk (id, user_code) 0..*--1 users (id, code)\n"
+	out := mustRender(t, "pg", mustParse(t, src))
+	if strings.Contains(out, "fk_articles_user_code") || strings.Contains(out, "fk_articles_id_user_code") {
+		t.Errorf("pg DDL references a column that is not unique:\n%s", out)
+	}
+	assertInOrder(t, out, []string{"    CONSTRAINT fk_articles_user_mail FOREIGN KEY (user_mail) REFERENCES users (mail)\n"})
+}
+
+func TestSQLiteHasNoAlterTable(t *testing.T) {
+	// SQLite は ALTER TABLE で制約を足せないので、循環していても CREATE TABLE の中に書く
+	if out := mustRender(t, "sqlite3", mustParse(t, testCyclicSource)); strings.Contains(out, "ALTER TABLE") {
+		t.Errorf("sqlite3 DDL has ALTER TABLE:\n%s", out)
+	}
+}
+
+func TestMySQLDDL(t *testing.T) {
+	out := mustRender(t, "mysql", mustParse(t, testErdMSource))
+	assertInOrder(t, out, []string{
+		"SET FOREIGN_KEY_CHECKS = 0;",
+		"DROP TABLE IF EXISTS `articles`;",
+		"CREATE TABLE `users` (",
+		"    `id` bigint NOT NULL AUTO_INCREMENT UNIQUE COMMENT 'member id',\n",
+		"    `created` datetime NOT NULL DEFAULT now(),\n",
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='site user master';",
+		"    PRIMARY KEY (`tenant_id`, `user_id`),\n",
+		"    INDEX `i_articles_owner` (`owner_user_id`),\n",
+		"    UNIQUE INDEX `i_articles_title` (`title`, `id`),\n",
+		"    CONSTRAINT `fk_articles_tenant_id_owner_user_id` FOREIGN KEY (`tenant_id`, `owner_user_id`) REFERENCES `tenant_users` (`tenant_id`, `user_id`)\n",
+		"SET FOREIGN_KEY_CHECKS = 1;",
+	})
+	quoted := mustRender(t, "mysql", mustParse(t, "# Title: t\n\nusers/\"it's\\me\"\n    +id [int][NN]\n"))
+	assertInOrder(t, quoted, []string{`COMMENT='it''s\\me';`})
+}
+
+func TestSQLServerAndOracleDDL(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"mssql", []string{
+			"IF OBJECT_ID(N'[articles]', N'U') IS NOT NULL DROP TABLE [articles];",
+			"CREATE TABLE [users] (",
+			"    [id] bigint IDENTITY(1,1) NOT NULL UNIQUE,\n",
+			"    [nick_name] nvarchar(128) NOT NULL,\n",
+			"    [created] datetime2 NOT NULL DEFAULT CURRENT_TIMESTAMP,\n",
+			"EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'site user master', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users';",
+			"@value = N'member id', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'users', @level2type = N'COLUMN', @level2name = N'id';",
+			"    CONSTRAINT [fk_articles_tenant_id_owner_user_id] FOREIGN KEY ([tenant_id], [owner_user_id]) REFERENCES [tenant_users] ([tenant_id], [user_id])\n",
+			"CREATE UNIQUE INDEX [i_articles_title] ON [articles] ([title], [id]);",
+		}},
+		{"oracle", []string{
+			"    EXECUTE IMMEDIATE 'DROP TABLE \"articles\" CASCADE CONSTRAINTS';\n",
+			"        IF SQLCODE != -942 THEN\n",
+			"CREATE TABLE \"users\" (",
+			"    \"id\" number(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL,\n",
+			"    \"created\" timestamp DEFAULT CURRENT_TIMESTAMP NOT NULL,\n",
+			"COMMENT ON TABLE \"users\" IS 'site user master';",
+			"COMMENT ON COLUMN \"users\".\"id\" IS 'member id';",
+			"    PRIMARY KEY (\"tenant_id\", \"user_id\"),\n",
+			"CREATE INDEX \"i_articles_owner\" ON \"articles\" (\"owner_user_id\");",
+		}},
+	}
+	s := mustParse(t, testErdMSource)
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assertInOrder(t, mustRender(t, tt.format, s), tt.want)
+		})
+	}
+}
+
+func TestOracleDropsUniqueOnPrimaryKey(t *testing.T) {
+	// 主キーと同じ列の UNIQUE は Oracle ではエラーになる
+	out := mustRender(t, "oracle", mustParse(t, testErdMSource))
+	if strings.Contains(out, "IDENTITY NOT NULL UNIQUE") {
+		t.Errorf("oracle DDL has UNIQUE on the primary key:\n%s", out)
+	}
+}
+
+func TestIdentityColumnHasNoDefault(t *testing.T) {
+	// IDENTITY 列に DEFAULT を付けると Oracle と SQL Server ではエラーになる
+	s := mustParse(t, "# Title: t\n\nt\n    +id [bigserial][NN][=1]\n    n [int][NN][=0]\n")
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"oracle", []string{"    \"id\" number(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL,\n", "    \"n\" number(10) DEFAULT 0 NOT NULL,\n"}},
+		{"mssql", []string{"    [id] bigint IDENTITY(1,1) NOT NULL,\n", "    [n] int NOT NULL DEFAULT 0,\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assertInOrder(t, mustRender(t, tt.format, s), tt.want)
+		})
+	}
+}
+
+func TestPostgreSQLComments(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"logical names and comments", testErdMSource, []string{
+			"COMMENT ON TABLE users IS 'site user master\nregistered members';",
+			"COMMENT ON COLUMN users.id IS 'member id';",
+			"COMMENT ON COLUMN users.password IS 'site password\nhashed';",
+			"COMMENT ON TABLE tenants IS 'tenant';",
+		}},
+		{"quote", "# Title: t\n\nusers/\"user's\"\n    +id [int][NN]\n        # it's the key\n", []string{
+			"COMMENT ON TABLE users IS 'user''s';",
+			"COMMENT ON COLUMN users.id IS 'it''s the key';",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertInOrder(t, mustRender(t, "pg", mustParse(t, tt.src)), tt.want)
+		})
+	}
+	// 論理名もコメントもなければ COMMENT ON は書かない
+	if out := mustRender(t, "pg", mustParse(t, "# Title: t\n\nusers\n    +id [int][NN]\n")); strings.Contains(out, "COMMENT ON") {
+		t.Errorf("pg DDL has COMMENT ON:\n%s", out)
+	}
+}
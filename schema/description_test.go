@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableComments(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		comments    string
+		lintDisable string
+	}{
+		{"none", "# Title: t\n\nusers\n    +id [int][NN]\n", "", ""},
+		{"lines under the header", "# Title: t\n\nusers\n    # site members\n  #registered  \n    +id [int][NN]\n", "site members/registered  ", ""},
+		{"empty line", "# Title: t\n\nusers\n    # a\n    #\n    # b\n    +id [int][NN]\n", "a//b", ""},
+		{"lint directive", "# Title: t\n\nuser\n    # members\n    # erdm-lint:disable plural-table\n    +id [int][NN]\n", "members", "plural-table"},
+		{"column comment is not a table comment", "# Title: t\n\nusers\n    +id [int][NN]\n        # key\n", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mustParse(t, tt.src).Tables[0]
+			if got := strings.Join(users.Comments, "/"); got != tt.comments {
+				t.Errorf("Comments = %q, want %q", got, tt.comments)
+			}
+			if got := strings.Join(users.LintDisable, "/"); got != tt.lintDisable {
+				t.Errorf("LintDisable = %q, want %q", got, tt.lintDisable)
+			}
+			if users.HasComment() != (len(tt.comments) > 0) {
+				t.Errorf("HasComment = %v", users.HasComment())
+			}
+		})
+	}
+}
+
+func TestTableCommentsAreRendered(t *testing.T) {
+	s := mustParse(t, "# Title: t\n\nusers/\"site user\"\n    # members\n    # \"quoted\"\n    +id [int][NN]\n")
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"html", []string{"<td>site user</td>", "<td colspan=\"2\">members<br/>&#34;quoted&#34;<br/></td>"}},
+		{"dot", []string{`users [URL = "#table-users", tooltip = "site user/users\nmembers\n\"quoted\"", label = <`}},
+		{"pg", []string{"COMMENT ON TABLE users IS 'site user\nmembers\n\"quoted\"';"}},
+		{"erdm", []string{"users/\"site user\"\n    # members\n    # \"quoted\"\n    +id [int][NN]\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assertInOrder(t, mustRender(t, tt.format, s), tt.want)
+		})
+	}
+}
+
+func TestTableDescriptionDBMLRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"logical name", "# Title: t\n\nusers/\"site user\"\n    # a\n    # b\n    +id [int][NN]\n"},
+		{"no logical name", "# Title: t\n\nusers\n    # a\n    # b\n    +id [int][NN]\n"},
+		{"one line", "# Title: t\n\nusers\n    # a\n    +id [int][NN]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustParse(t, tt.src)
+			d, ds := ParseDBML(strings.NewReader(mustRender(t, "dbml", s)))
+			if d == nil {
+				t.Fatalf("ParseDBML failed: %v", ds)
+			}
+			if want, got := mustRender(t, "json", s), mustRender(t, "json", d); want != got {
+				t.Errorf("json differs after the DBML round trip:\n%s\n---\n%s", want, got)
+			}
+		})
+	}
+}
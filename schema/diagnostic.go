@@ -0,0 +1,349 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Position は .erdm の中の位置。行と桁は 1 から数え、桁は文字数で数える。
+// DBML など .erdm 以外から読んだ定義では 0 のまま。
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Diagnostic は入力ファイルの問題 1 件。
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity Severity
+	Message  string
+	Expected []string
+	// Rule は lint のルール名。構文エラーや validate の結果では空
+	Rule string
+}
+
+func newDiagnostic(severity Severity, pos Position, format string, a ...interface{}) Diagnostic {
+	return Diagnostic{Line: pos.Line, Column: pos.Column, Severity: severity, Message: fmt.Sprintf(format, a...)}
+}
+
+// String はエディタが解釈できる file:line:col: severity: message の形式で返す。
+// File が空なら line:col: から始める。
+func (d Diagnostic) String() string {
+	s := ""
+	if len(d.File) > 0 {
+		s = d.File + ":"
+	}
+	if d.Line > 0 {
+		s += fmt.Sprintf("%d:%d:", d.Line, d.Column)
+	}
+	if len(s) > 0 {
+		s += " "
+	}
+	s += d.Severity.String() + ": " + d.Message
+	if len(d.Expected) > 0 {
+		s += " (expected " + strings.Join(d.Expected, ", ") + ")"
+	}
+	if len(d.Rule) > 0 {
+		s += " [" + d.Rule + "]"
+	}
+	return s
+}
+
+// Diagnostics は Parse が返すエラーと警告。1 行に 1 件ずつ表示する。
+type Diagnostics []Diagnostic
+
+func (ds Diagnostics) Error() string {
+	ss := []string{}
+	for _, d := range ds {
+		ss = append(ss, d.String())
+	}
+	return strings.Join(ss, "\n")
+}
+
+func (ds Diagnostics) SetFile(filename string) {
+	for i := range ds {
+		ds[i].File = filename
+	}
+}
+
+// sortDiagnostics はファイル中の位置の順に並べる。
+func sortDiagnostics(ds []Diagnostic) {
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].Line != ds[j].Line {
+			return ds[i].Line < ds[j].Line
+		}
+		return ds[i].Column < ds[j].Column
+	})
+}
+
+func (ds Diagnostics) HasError() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// lineStarts は buffer の各行の先頭の位置を返す。
+func lineStarts(buffer []rune) []int {
+	starts := []int{0}
+	for i, r := range buffer {
+		if r == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// lineColumn は buffer の pos 文字目の行と桁を返す。starts は lineStarts(buffer) の結果。
+func lineColumn(buffer []rune, starts []int, pos int) (int, int) {
+	line := sort.Search(len(starts), func(i int) bool { return starts[i] > pos })
+	column := 1
+	for _, r := range buffer[starts[line-1]:pos] {
+		if r != '\r' {
+			column++
+		}
+	}
+	return line, column
+}
+
+// unexpected は rs の先頭の語を表示用に切り出す。
+func unexpected(rs []rune) string {
+	s := strings.TrimRight(string(rs), "\r\n"+string(endSymbol))
+	if i := strings.IndexAny(s, " \t\r\n"); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) == 0 {
+		return "unexpected end of line"
+	}
+	if r := []rune(s); len(r) > 20 {
+		s = string(r[:20]) + "..."
+	}
+	return fmt.Sprintf("unexpected %q", s)
+}
+
+func (p *parser) addDiagnostic(pos int, message string, expected []string) {
+	d := newDiagnostic(SeverityError, p.position(pos), "%s", message)
+	d.Expected = expected
+	p.diagnostics = append(p.diagnostics, d)
+}
+
+// addSyntaxError は begin から始まる解析できなかった部分を報告する。
+// 空白しか残っていなければ（空のファイルなど）ファイルの終わりとして報告する。
+func (p *parser) addSyntaxError(begin, end int, expected ...string) {
+	message := unexpected(p.buffer[begin:end])
+	if len(strings.TrimSpace(strings.TrimRight(string(p.buffer[begin:]), string(endSymbol)))) == 0 {
+		message = "unexpected end of file"
+	}
+	p.addDiagnostic(begin, message, expected)
+}
+
+// locate は 1 行分の text を rule で解析し直し、解析できた最後のトークンを返す。
+// 行全体が rule に一致した場合は false を返す。
+func locate(text []rune, rule pegRule) (token32, bool) {
+	sub := &parser{Buffer: string(text)}
+	sub.Init()
+	err := sub.Parse(int(rule))
+	if pe, ok := err.(*parseError); ok {
+		return pe.max, true
+	}
+	return token32{}, false
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+// lastRune は空白を除いた s の最後の文字を返す。
+func lastRune(s string) rune {
+	rs := []rune(strings.TrimRight(s, " \t"))
+	if len(rs) == 0 {
+		return 0
+	}
+	return rs[len(rs)-1]
+}
+
+// skipColumnList は pos から続く空白と、(a, b) の ( , ) を読めたことにして、次のトークンの位置を返す。
+// 記号はトークンにならないので、locate の結果だけでは記号の手前で止まる。
+func skipColumnList(text []rune, pos int) int {
+	for {
+		i := pos
+		for i < len(text) && (text[i] == ' ' || text[i] == '\t') {
+			i++
+		}
+		if i >= len(text) {
+			return i
+		}
+		prev := lastRune(string(text[:pos]))
+		switch {
+		case text[i] == '(' && prev != '(' && prev != ',':
+		case (text[i] == ',' || text[i] == ')') && isNameRune(prev):
+		default:
+			return i
+		}
+		pos = i + 1
+	}
+}
+
+// columnListExpected は (a, b) の途中まで読んだ head の後ろに書けるものを返す。
+func columnListExpected(head string) []string {
+	if r := lastRune(head); r == '(' || r == ',' {
+		return []string{"column name"}
+	}
+	return []string{"','", "')'"}
+}
+
+// addColumnError はカラムの行として解析できなかった行を、解析が止まった位置で報告する。
+func (p *parser) addColumnError(begin, end int) {
+	text := p.buffer[begin:end]
+	max, ok := locate(text, rulecolumn_info)
+	if !ok {
+		p.addSyntaxError(begin, end)
+		return
+	}
+	pos := int(max.end)
+	expected := []string{}
+	switch max.pegRule {
+	case rulespace:
+		if max.begin == 0 {
+			expected = append(expected, "column name")
+		} else {
+			expected = append(expected, "'['")
+		}
+	case rulepkey:
+		expected = append(expected, "column name")
+	case rulereal_column_name:
+		expected = append(expected, "'/' and logical name", "' [' and type")
+	case rulecolumn_name:
+		expected = append(expected, "' [' and type")
+	case rulecol_type, ruledefault:
+		expected = append(expected, "']'")
+	}
+	p.addDiagnostic(begin+pos, unexpected(text[pos:]), expected)
+}
+
+// addIndexError はインデックスの行として解析できなかった行を報告する。
+func (p *parser) addIndexError(begin, end int) {
+	text := p.buffer[begin:end]
+	line := strings.TrimLeft(string(text), " \t")
+	if head := strings.ToLower(strings.Fields(line)[0]); head == "fk" || strings.HasPrefix(head, "fk(") {
+		p.addCompositeRelationError(begin, end)
+		return
+	}
+	if fs := strings.Fields(line); strings.ToLower(fs[0]) != "index" {
+		p.addDiagnostic(end-len([]rune(line)), "columns must be written before indexes", nil)
+		return
+	}
+	max, ok := locate(text, ruleindex_info)
+	if !ok {
+		p.addSyntaxError(begin, end)
+		return
+	}
+	pos := skipColumnList(text, int(max.end))
+	head := string(text[:pos])
+	expected := []string{}
+	switch {
+	case max.pegRule == rulespace && strings.ToLower(strings.TrimSpace(head)) == "index":
+		expected = append(expected, "index name")
+	case !strings.Contains(head, "("):
+		expected = append(expected, "'('")
+	default:
+		expected = append(expected, columnListExpected(head)...)
+	}
+	p.addDiagnostic(begin+pos, unexpected(text[pos:]), expected)
+}
+
+// addCompositeRelationError は fk (...) の行として解析できなかった行を報告する。
+func (p *parser) addCompositeRelationError(begin, end int) {
+	text := p.buffer[begin:end]
+	max, ok := locate(text, rulecomposite_relation_info)
+	if !ok {
+		p.addSyntaxError(begin, end)
+		return
+	}
+	pos := skipColumnList(text, int(max.end))
+	// -- は記号なのでトークンにならない。多重度の直後の -- は読めたことにする
+	if rest := string(text[pos:]); strings.HasPrefix(strings.TrimLeft(rest, " \t"), "--") {
+		pos += len([]rune(rest)) - len([]rune(strings.TrimLeft(strings.TrimLeft(rest, " \t")[2:], " \t")))
+	}
+	head := string(text[:pos])
+	expected := []string{}
+	switch {
+	case !strings.Contains(head, "("):
+		expected = append(expected, "'('")
+	case strings.Contains(head, "--"):
+		expected = append(expected, "table name")
+	case strings.Contains(head, ")"):
+		expected = append(expected, "relation")
+	default:
+		expected = append(expected, columnListExpected(head)...)
+	}
+	p.addDiagnostic(begin+pos, unexpected(text[pos:]), expected)
+}
+
+// position は begin の行と桁を返す。行の先頭の位置は最初に呼ばれたときに一度だけ数える。
+func (p *parser) position(begin int) Position {
+	if p.lines == nil {
+		p.lines = lineStarts(p.buffer)
+	}
+	line, column := lineColumn(p.buffer, p.lines, begin)
+	return Position{Line: line, Column: column}
+}
+
+func (p *parser) setTablePosition(begin int) {
+	t := &p.Tables[p.currentTableId]
+	t.Position = p.position(begin)
+	t.BlankLineBefore = p.blankLineBefore(t.Position)
+}
+
+func (p *parser) setColumnPosition(begin int) {
+	t := &p.Tables[p.currentTableId]
+	c := &t.Columns[p.currentColumnId]
+	c.Position = p.position(begin)
+	c.BlankLineBefore = p.blankLineBefore(c.Position)
+}
+
+// setCommentPosition はカラムの # コメント行まで読んだことにする。行の位置は空行の判定にだけ使う。
+func (p *parser) setCommentPosition(begin int) {
+	p.blankLineBefore(p.position(begin))
+}
+
+func (p *parser) setRelationPosition(begin int) {
+	t := &p.Tables[p.currentTableId]
+	t.Columns[p.currentColumnId].Relation.Position = p.position(begin)
+}
+
+func (p *parser) setCompositeRelationPosition(begin int) {
+	r := p.currentCompositeRelation()
+	r.Position = p.position(begin)
+	r.BlankLineBefore = p.blankLineBefore(r.Position)
+}
+
+func (p *parser) setCompositeRelationTablePosition(begin int) {
+	p.currentCompositeRelation().TablePosition = p.position(begin)
+}
+
+func (p *parser) setIndexPosition(begin int) {
+	t := &p.Tables[p.currentTableId]
+	i := &t.Indexes[p.currentIndexId]
+	i.Position = p.position(begin)
+	i.BlankLineBefore = p.blankLineBefore(i.Position)
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"empty file", "", "1:1: error: unexpected end of file (expected '# Title:')"},
+		{"blank lines only", "\n\n", "1:1: error: unexpected end of file (expected '# Title:')"},
+		{"no title", "users\n    +id [int]\n", `1:1: error: unexpected "users" (expected '# Title:')`},
+		{"title without colon", "# Title x\n", `1:1: error: unexpected "#" (expected '# Title:')`},
+		{"unterminated type", "# Title: x\n\nusers\n    +id [int\n", "4:13: error: unexpected end of line (expected ']')"},
+		{"column without type", "# Title: x\n\nusers\n    +id\n", "4:8: error: unexpected end of line (expected '/' and logical name, ' [' and type)"},
+		{"broken relation", "# Title: x\n\nusers\n    +id [int][NN]\n    name [text] 0..*-1 users\n", `5:17: error: unexpected "0..*-1" (expected '[NN]', '[U]', '[=default]', '[-erd]', relation, end of line)`},
+		{"unterminated index", "# Title: x\n\nusers\n    +id [int][NN]\n    index i_users (id\n", "5:22: error: unexpected end of line (expected ',', ')')"},
+		{"index column without ','", "# Title: x\n\nusers\n    +id [int][NN]\n    name [text]\n    index i_users (id name)\n", `6:23: error: unexpected "name)" (expected ',', ')')`},
+		{"index without last column", "# Title: x\n\nusers\n    +id [int][NN]\n    index i_users (id,\n", "5:23: error: unexpected end of line (expected column name)"},
+		{"column after index", "# Title: x\n\nusers\n    +id [int][NN]\n    index i_users (id)\n    name [text]\n", "6:5: error: columns must be written before indexes"},
+		{"table without columns", "# Title: x\n\n  users\n", "3:3: error: table users has no columns"},
+		{"duplicate table", "# Title: x\n\nusers\n    +id [int][NN]\n\nusers\n    +id [int][NN]\n", "6:1: error: table users is already defined at line 3"},
+		{"duplicate column", "# Title: x\n\nusers\n    +id [int][NN]\n    id [int]\n", "5:5: error: column users.id is already defined at line 4"},
+		{"unknown table", "# Title: x\n\nusers\n    +id [int][NN] 0..*--1 nope\n", "4:27: error: users.id references unknown table nope"},
+		{"unknown index column", "# Title: x\n\nusers\n    +id [int][NN]\n    index i_users (nope)\n", "5:11: error: index i_users: unknown column users.nope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ds := Parse(strings.NewReader(tt.src))
+			if s != nil {
+				t.Fatalf("Parse(%q) succeeded", tt.src)
+			}
+			if got := Diagnostics(ds).Error(); got != tt.want {
+				t.Errorf("Parse(%q) =\n%s\nwant\n%s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTitleOnly(t *testing.T) {
+	for _, src := range []string{"# Title: x", "# Title: x\n", "#Title:x\r\n"} {
+		s, ds := Parse(strings.NewReader(src))
+		if s == nil {
+			t.Errorf("Parse(%q) failed: %v", src, ds)
+			continue
+		}
+		if s.Title != "x" || len(s.Tables) != 0 {
+			t.Errorf("Parse(%q) = %q with %d tables", src, s.Title, len(s.Tables))
+		}
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Diagnostic
+		want string
+	}{
+		{"position", Diagnostic{Line: 3, Column: 5, Message: "m"}, "3:5: error: m"},
+		{"file", Diagnostic{File: "a.erdm", Line: 3, Column: 5, Severity: SeverityWarning, Message: "m"}, "a.erdm:3:5: warning: m"},
+		{"file without position", Diagnostic{File: "a.dbml", Message: "m"}, "a.dbml: error: m"},
+		{"no position", Diagnostic{Message: "m"}, "error: m"},
+		{"expected and rule", Diagnostic{Line: 1, Column: 1, Message: "m", Expected: []string{"'('", "','"}, Rule: "r"}, "1:1: error: m (expected '(', ',') [r]"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("%s: String = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiagnosticsSetFile(t *testing.T) {
+	ds := Diagnostics{{Line: 2, Column: 1, Severity: SeverityWarning, Message: "a"}, {Line: 1, Column: 3, Message: "b"}}
+	if ds[:1].HasError() || !ds.HasError() {
+		t.Errorf("HasError is wrong for %v", ds)
+	}
+	ds.SetFile("x.erdm")
+	sortDiagnostics(ds)
+	if got, want := ds.Error(), "x.erdm:1:3: error: b\nx.erdm:2:1: warning: a"; got != want {
+		t.Errorf("Error = %q, want %q", got, want)
+	}
+}
+
+func TestLineColumn(t *testing.T) {
+	// 桁は文字数で数え、CR は数えない
+	buffer := []rune("ab\r\nあい\n")
+	tests := []struct {
+		pos          int
+		line, column int
+	}{
+		{0, 1, 1},
+		{2, 1, 3},
+		{4, 2, 1},
+		{5, 2, 2},
+		{7, 3, 1},
+	}
+	starts := lineStarts(buffer)
+	for _, tt := range tests {
+		if line, column := lineColumn(buffer, starts, tt.pos); line != tt.line || column != tt.column {
+			t.Errorf("lineColumn(%d) = %d:%d, want %d:%d", tt.pos, line, column, tt.line, tt.column)
+		}
+	}
+}
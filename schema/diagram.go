@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// splitCardinality は "0..*" のような多重度を最小・最大に分ける。"1" のような単独の値は最小・最大とも同じ。
+func splitCardinality(c string) (string, string) {
+	if i := strings.Index(c, ".."); i >= 0 {
+		return c[:i], c[i+2:]
+	}
+	return c, c
+}
+
+// crowsFoot は多重度を Mermaid / PlantUML 共通の crow's foot 記法の片側にする。
+// left は線の左側（参照元テーブル側）の向きで書くかどうか。
+func crowsFoot(c string, left bool) string {
+	min, max := splitCardinality(c)
+	zero := min == "0" || min == "*"
+	many := max == "*"
+	switch {
+	case zero && many:
+		if left {
+			return "}o"
+		}
+		return "o{"
+	case many:
+		if left {
+			return "}|"
+		}
+		return "|{"
+	case zero:
+		if left {
+			return "|o"
+		}
+		return "o|"
+	}
+	return "||"
+}
+
+// crowsFootSource は参照元テーブル側の記号を返す。多重度が省略されていれば 0..* とみなす。
+func crowsFootSource(c string) string {
+	if len(c) == 0 {
+		c = "0..*"
+	}
+	return crowsFoot(c, true)
+}
+
+// crowsFootDestination は参照先テーブル側の記号を返す。多重度が省略されていれば 1 とみなす。
+func crowsFootDestination(c string) string {
+	if len(c) == 0 {
+		c = "1"
+	}
+	return crowsFoot(c, false)
+}
+
+func (r *TableRelation) GetCrowsFootSource() string {
+	return crowsFootSource(r.CardinalitySource)
+}
+
+func (r *TableRelation) GetCrowsFootDestination() string {
+	return crowsFootDestination(r.CardinalityDestination)
+}
+
+func (r *CompositeRelation) GetCrowsFootSource() string {
+	return crowsFootSource(r.CardinalitySource)
+}
+
+func (r *CompositeRelation) GetCrowsFootDestination() string {
+	return crowsFootDestination(r.CardinalityDestination)
+}
+
+// GetColumns は参照元のカラムをカンマ区切りで返す。
+func (r *CompositeRelation) GetColumns() string {
+	return strings.Join(r.Columns, ", ")
+}
+
+// GetReferenceColumns は参照先のカラムをカンマ区切りで返す。省略されていれば空。
+func (r *CompositeRelation) GetReferenceColumns() string {
+	return strings.Join(r.ReferenceColumns, ", ")
+}
+
+// GetStereotypes は PlantUML の <<PK>> などのステレオタイプを返す。
+func (c *Column) GetStereotypes() string {
+	ss := []string{}
+	if c.IsPrimaryKey {
+		ss = append(ss, "<<PK>>")
+	}
+	if c.IsForeignKey {
+		ss = append(ss, "<<FK>>")
+	}
+	if c.IsUnique {
+		ss = append(ss, "<<unique>>")
+	}
+	return strings.Join(ss, " ")
+}
+
+// GetKeyMarkers は Mermaid の属性キー（PK, FK, UK）をカンマ区切りで返す。
+func (c *Column) GetKeyMarkers() string {
+	ks := []string{}
+	if c.IsPrimaryKey {
+		ks = append(ks, "PK")
+	}
+	if c.IsForeignKey {
+		ks = append(ks, "FK")
+	}
+	if c.IsUnique {
+		ks = append(ks, "UK")
+	}
+	return strings.Join(ks, ",")
+}
+
+var mermaidTypeReplacer = regexp.MustCompile(`[^A-Za-z0-9_()\-\[\]]+`)
+
+// mermaidType は Mermaid の属性型として使えない空白やカンマを _ に置き換える。
+func mermaidType(t string) string {
+	return mermaidTypeReplacer.ReplaceAllString(strings.TrimSpace(t), "_")
+}
+
+// GetTooltip は dot のテーブルの tooltip を返す。説明の行は改行して続ける。
+func (t *Table) GetTooltip() string {
+	s := t.TitleReal
+	if len(t.Title) > 0 {
+		s = t.Title + "/" + s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	lines := []string{}
+	for _, l := range append([]string{s}, t.Comments...) {
+		lines = append(lines, r.Replace(l))
+	}
+	return strings.Join(lines, `\n`)
+}
+
+// quoteLabel は Mermaid / PlantUML の "..." に入れられるよう " を ' に置き換える。
+func quoteLabel(s string) string {
+	return "\"" + strings.Replace(s, "\"", "'", -1) + "\""
+}
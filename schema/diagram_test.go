@@ -0,0 +1,109 @@
+package schema
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+)
+
+func TestDotLinksTables(t *testing.T) {
+	out := mustRender(t, "dot", mustParse(t, testErdMSource))
+	assertInOrder(t, out, []string{
+		`users [URL = "#table-users", tooltip = "site user master/users\nregistered members", label = <`,
+		`tenants [URL = "#table-tenants", tooltip = "tenant/tenants", label = <`,
+	})
+}
+
+func TestGetTooltip(t *testing.T) {
+	tests := []struct {
+		table Table
+		want  string
+	}{
+		{Table{TitleReal: "users"}, `users`},
+		{Table{TitleReal: "users", Title: "site user"}, `site user/users`},
+		{Table{TitleReal: "users", Title: `"a\b"`}, `\"a\\b\"/users`},
+	}
+	for _, tt := range tests {
+		if got := tt.table.GetTooltip(); got != tt.want {
+			t.Errorf("GetTooltip(%+v) = %s, want %s", tt.table, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLEmbedsSVG(t *testing.T) {
+	s := mustParse(t, testErdMSource)
+	s.ImageFilename = "erd.png"
+	s.ImageSVG = htmltemplate.HTML(`<svg id="erd"></svg>`)
+	out := mustRender(t, "html", s)
+	assertInOrder(t, out, []string{
+		`<div class="erd-svg"><svg id="erd"></svg></div>`,
+		`<div class="table-block" id="table-users"`,
+	})
+	if strings.Contains(out, `<img src="erd.png"`) {
+		t.Errorf("html has both the SVG and the image")
+	}
+	// SVG がなければ画像を表示する
+	s.ImageSVG = ""
+	assertInOrder(t, mustRender(t, "html", s), []string{`<img src="erd.png"`})
+}
+
+func TestCrowsFoot(t *testing.T) {
+	tests := []struct {
+		cardinality  string
+		source, dest string
+	}{
+		{"", "}o", "||"},
+		{"0..*", "}o", "o{"},
+		{"*", "}o", "o{"},
+		{"1..*", "}|", "|{"},
+		{"0..1", "|o", "o|"},
+		{"1", "||", "||"},
+	}
+	for _, tt := range tests {
+		if got := crowsFootSource(tt.cardinality); got != tt.source {
+			t.Errorf("crowsFootSource(%q) = %s, want %s", tt.cardinality, got, tt.source)
+		}
+		if tt.cardinality == "" {
+			continue
+		}
+		if got := crowsFootDestination(tt.cardinality); got != tt.dest {
+			t.Errorf("crowsFootDestination(%q) = %s, want %s", tt.cardinality, got, tt.dest)
+		}
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	out := mustRender(t, "mermaid", mustParse(t, testErdMSource))
+	assertInOrder(t, out, []string{
+		"title: \"ER Sample\"\n",
+		"erDiagram\n",
+		"    users[\"site user master\"] {\n",
+		"        bigserial id PK,UK \"member id\"\n",
+		"        varchar(128) nick_name \"nickname\"\n",
+		"        bigint tenant_id PK,FK\n",
+		"    tenant_users }o--|| tenants : \"tenant_id\"\n",
+		"    articles }o--o| tenant_users : \"tenant_id, owner_user_id\"\n",
+	})
+	// Mermaid の型には空白を書けない
+	out = mustRender(t, "mermaid", mustParse(t, "# Title: t\n\nevents\n    +id [int][NN]\n    at [timestamp with time zone]\n"))
+	assertInOrder(t, out, []string{"        timestamp_with_time_zone at\n"})
+}
+
+func TestPlantUML(t *testing.T) {
+	out := mustRender(t, "plantuml", mustParse(t, testErdMSource))
+	assertInOrder(t, out, []string{
+		"@startuml\n",
+		"title ER Sample\n",
+		"entity \"site user master\" as users {\n",
+		"  * member id/id : bigserial <<PK>> <<unique>>\n  --\n",
+		"  site password/password : varchar(128)\n",
+		"entity \"tenant user\" as tenant_users {\n",
+		"  * tenant_id : bigint <<PK>> <<FK>>\n",
+		"tenant_users }o--|| tenants : tenant_id\n",
+		"articles }o--o| tenant_users : tenant_id, owner_user_id\n",
+		"@enduml\n",
+	})
+	// エンティティ名の " は ' にする
+	out = mustRender(t, "plantuml", &Schema{Title: "t", Tables: []Table{{TitleReal: "users", Title: `a "b"`}}})
+	assertInOrder(t, out, []string{"entity \"a 'b'\" as users {\n"})
+}
@@ -0,0 +1,308 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 2 つの Schema の差分。マイグレーション SQL と review の要約に使う。
+// テーブルやカラムの名前の変更は区別できないので、削除と追加として扱う。
+type SchemaDiff struct {
+	Old           *Schema
+	New           *Schema
+	AddedTables   []Table
+	DroppedTables []Table
+	ChangedTables []TableDiff
+}
+
+type TableDiff struct {
+	Old                Table
+	New                Table
+	AddedColumns       []Column
+	DroppedColumns     []Column
+	ChangedColumns     []ColumnDiff
+	PrimaryKeyChanged  bool
+	DescriptionChanged bool
+	AddedIndexes       []Index
+	DroppedIndexes     []Index
+	// AddedRelations / DroppedRelations は fk (...) の変更。FOREIGN KEY の変更は AddedForeignKeys / DroppedForeignKeys にも入る
+	AddedRelations     []CompositeRelation
+	DroppedRelations   []CompositeRelation
+	AddedForeignKeys   []ForeignKey
+	DroppedForeignKeys []ForeignKey
+}
+
+type ColumnDiff struct {
+	Old                Column
+	New                Column
+	TypeChanged        bool
+	NotNullChanged     bool
+	UniqueChanged      bool
+	DefaultChanged     bool
+	DescriptionChanged bool
+	RelationChanged    bool
+}
+
+// migrationTemplates は diff の -dialect ごとのテンプレート。
+var migrationTemplates = map[string]string{
+	"pg":    "pg_migration",
+	"mysql": "mysql_migration",
+}
+
+// MigrationDialects は Migration で指定できる方言を返す。
+func MigrationDialects() []string {
+	ds := []string{}
+	for _, d := range dialects {
+		if _, ok := migrationTemplates[d]; ok {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+func sameType(a, b *Column) bool {
+	if a.Canonical.IsKnown() && b.Canonical.IsKnown() {
+		return a.Canonical.key() == b.Canonical.key() && a.Canonical.args() == b.Canonical.args()
+	}
+	return normalizeType(a.Type) == normalizeType(b.Type)
+}
+
+func sameStrings(a, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+// sameRelation は書かれた位置を除いてリレーションを比べる。
+func sameRelation(a, b TableRelation) bool {
+	a.Position, b.Position = Position{}, Position{}
+	return a == b
+}
+
+func diffColumn(o, n *Column) ColumnDiff {
+	return ColumnDiff{
+		Old:                *o,
+		New:                *n,
+		TypeChanged:        !sameType(o, n),
+		NotNullChanged:     o.AllowNull != n.AllowNull,
+		UniqueChanged:      o.IsUnique != n.IsUnique,
+		DefaultChanged:     o.Default != n.Default,
+		DescriptionChanged: o.GetDescription() != n.GetDescription(),
+		RelationChanged:    !sameRelation(o.Relation, n.Relation),
+	}
+}
+
+func (d *ColumnDiff) IsChanged() bool {
+	return d.TypeChanged || d.NotNullChanged || d.UniqueChanged || d.DefaultChanged || d.DescriptionChanged || d.RelationChanged
+}
+
+// GetChanges は review で表示する変更点の説明を返す。
+func (d *ColumnDiff) GetChanges() []string {
+	o, n := &d.Old, &d.New
+	cs := []string{}
+	if d.TypeChanged {
+		cs = append(cs, "type "+o.Type+" -> "+n.Type)
+	}
+	if d.NotNullChanged {
+		cs = append(cs, flagChange("[NN]", !n.AllowNull))
+	}
+	if d.UniqueChanged {
+		cs = append(cs, flagChange("[U]", n.IsUnique))
+	}
+	if d.DefaultChanged {
+		cs = append(cs, "default "+orNone(o.Default)+" -> "+orNone(n.Default))
+	}
+	if d.DescriptionChanged {
+		if o.Title != n.Title {
+			cs = append(cs, "logical name "+orNone(o.Title)+" -> "+orNone(n.Title))
+		}
+		if d.IsCommentChanged() {
+			cs = append(cs, "comments changed")
+		}
+	}
+	if d.RelationChanged {
+		r := "relation " + orNone(o.Relation.GetErdmText()) + " -> " + orNone(n.Relation.GetErdmText())
+		if !o.HasRelation() {
+			r = "relation " + n.Relation.GetErdmText() + " added"
+		} else if !n.HasRelation() {
+			r = "relation " + o.Relation.GetErdmText() + " removed"
+		}
+		cs = append(cs, r)
+	}
+	return cs
+}
+
+func (d *ColumnDiff) IsCommentChanged() bool {
+	return !sameStrings(d.Old.Comments, d.New.Comments)
+}
+
+func flagChange(f string, added bool) string {
+	if added {
+		return f + " added"
+	}
+	return f + " removed"
+}
+
+func orNone(s string) string {
+	if len(s) == 0 {
+		return "(none)"
+	}
+	return s
+}
+
+// GetAlterType は ALTER COLUMN ... TYPE に使う型を返す。serial は型ではないので元の整数型にする。
+func (d *ColumnDiff) GetAlterType(dialect string) string {
+	t, _ := d.New.Canonical.baseType().mapTo(dialect)
+	return t
+}
+
+// NeedsBackfill は既存の行があると NOT NULL のまま追加できない列かどうかを返す。
+// このような列は NULL を許して追加し、値を埋めてから NOT NULL にする。
+func (c *Column) NeedsBackfill() bool {
+	return !c.AllowNull && !c.HasDefaultSetting() && !c.IsSerial()
+}
+
+// GetNullable は NULL を許すようにした列を返す。
+func (c *Column) GetNullable() *Column {
+	n := *c
+	n.AllowNull = true
+	return &n
+}
+
+func diffTable(o, n *Table) TableDiff {
+	d := TableDiff{Old: *o, New: *n}
+	for ci := range n.Columns {
+		c := &n.Columns[ci]
+		oi, err := o.getColumnIndex(c.TitleReal)
+		if err != nil {
+			d.AddedColumns = append(d.AddedColumns, *c)
+			continue
+		}
+		if cd := diffColumn(&o.Columns[oi], c); cd.IsChanged() {
+			d.ChangedColumns = append(d.ChangedColumns, cd)
+		}
+	}
+	for _, c := range o.Columns {
+		if _, err := n.getColumnIndex(c.TitleReal); err != nil {
+			d.DroppedColumns = append(d.DroppedColumns, c)
+		}
+	}
+	d.PrimaryKeyChanged = !sameStrings(o.GetPrimaryKeyColumnNames(), n.GetPrimaryKeyColumnNames())
+	d.DescriptionChanged = o.GetDescription() != n.GetDescription()
+
+	// 定義の変わったインデックスと外部キーは削除してから作り直す
+	for _, i := range n.Indexes {
+		found := false
+		for _, oi := range o.Indexes {
+			if oi.Title == i.Title && oi.IsUnique == i.IsUnique && sameStrings(oi.Columns, i.Columns) {
+				found = true
+			}
+		}
+		if !found {
+			d.AddedIndexes = append(d.AddedIndexes, i)
+		}
+	}
+	for _, oi := range o.Indexes {
+		found := false
+		for _, i := range n.Indexes {
+			if oi.Title == i.Title && oi.IsUnique == i.IsUnique && sameStrings(oi.Columns, i.Columns) {
+				found = true
+			}
+		}
+		if !found {
+			d.DroppedIndexes = append(d.DroppedIndexes, oi)
+		}
+	}
+	for _, r := range n.CompositeRelations {
+		if !hasCompositeRelation(o.CompositeRelations, r) {
+			d.AddedRelations = append(d.AddedRelations, r)
+		}
+	}
+	for _, r := range o.CompositeRelations {
+		if !hasCompositeRelation(n.CompositeRelations, r) {
+			d.DroppedRelations = append(d.DroppedRelations, r)
+		}
+	}
+	for _, f := range n.ForeignKeys {
+		if !hasForeignKey(o.ForeignKeys, f) {
+			d.AddedForeignKeys = append(d.AddedForeignKeys, f)
+		}
+	}
+	for _, f := range o.ForeignKeys {
+		if !hasForeignKey(n.ForeignKeys, f) {
+			d.DroppedForeignKeys = append(d.DroppedForeignKeys, f)
+		}
+	}
+	return d
+}
+
+// hasForeignKey は同じ名前・同じ参照先の外部キーがあるかを返す。多重度の違いは制約に影響しない。
+func hasForeignKey(fs []ForeignKey, f ForeignKey) bool {
+	for _, g := range fs {
+		if g.Name == f.Name && g.TableNameReal == f.TableNameReal && sameStrings(g.Columns, f.Columns) && sameStrings(g.ReferenceColumns, f.ReferenceColumns) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCompositeRelation は多重度まで同じ fk (...) があるかを返す。
+func hasCompositeRelation(rs []CompositeRelation, r CompositeRelation) bool {
+	for _, s := range rs {
+		if s.GetErdmText() == r.GetErdmText() {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *TableDiff) IsTitleChanged() bool {
+	return d.Old.Title != d.New.Title
+}
+
+func (d *TableDiff) IsCommentChanged() bool {
+	return !sameStrings(d.Old.Comments, d.New.Comments)
+}
+
+func (d *TableDiff) IsChanged() bool {
+	return len(d.AddedColumns) > 0 || len(d.DroppedColumns) > 0 || len(d.ChangedColumns) > 0 || d.PrimaryKeyChanged || d.DescriptionChanged ||
+		len(d.AddedIndexes) > 0 || len(d.DroppedIndexes) > 0 || len(d.AddedRelations) > 0 || len(d.DroppedRelations) > 0 || len(d.AddedForeignKeys) > 0 || len(d.DroppedForeignKeys) > 0
+}
+
+// Diff は old から new への差分を求める。追加テーブルは new の CREATE TABLE 順、
+// 削除テーブルは old の DROP TABLE 順に並べる。
+func Diff(o, n *Schema) *SchemaDiff {
+	d := &SchemaDiff{Old: o, New: n}
+	for _, t := range n.GetSortedTables() {
+		oi := o.getTableIndex(t.TitleReal)
+		if oi < 0 {
+			d.AddedTables = append(d.AddedTables, t)
+			continue
+		}
+		if td := diffTable(&o.Tables[oi], &t); td.IsChanged() {
+			d.ChangedTables = append(d.ChangedTables, td)
+		}
+	}
+	for _, t := range o.GetReverseSortedTables() {
+		if n.getTableIndex(t.TitleReal) < 0 {
+			d.DroppedTables = append(d.DroppedTables, t)
+		}
+	}
+	return d
+}
+
+func (d *SchemaDiff) IsEmpty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.ChangedTables) == 0
+}
+
+// Migration は差分を dialect（MigrationDialects の方言）のマイグレーション SQL として w に書き出す。
+func (d *SchemaDiff) Migration(dialect string, w io.Writer) error {
+	name, ok := migrationTemplates[dialect]
+	if !ok {
+		return fmt.Errorf("unknown dialect: %s (available: %s)", dialect, strings.Join(MigrationDialects(), ","))
+	}
+	t, _, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	return t.ExecuteTemplate(w, name, d)
+}
@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDiffOld = `# Title: t
+
+users
+    +id [bigint][NN]
+    name [text]
+    age [int]
+    memo [text][='x']
+
+groups
+    +id [bigint][NN]
+`
+
+const testDiffNew = `# Title: t
+
+users/"site user"
+    +id [bigint][NN]
+    name [text][NN][='anon']
+    age [bigint][NN]
+    memo [text]
+    tag_id [bigint] 0..*--1 tags
+    index i_users_tag_id (tag_id)
+
+tags
+    +id [bigint][NN]
+`
+
+func TestDiff(t *testing.T) {
+	d := Diff(mustParse(t, testDiffOld), mustParse(t, testDiffNew))
+	if len(d.AddedTables) != 1 || d.AddedTables[0].TitleReal != "tags" {
+		t.Errorf("added tables = %+v", d.AddedTables)
+	}
+	if len(d.DroppedTables) != 1 || d.DroppedTables[0].TitleReal != "groups" {
+		t.Errorf("dropped tables = %+v", d.DroppedTables)
+	}
+	if len(d.ChangedTables) != 1 {
+		t.Fatalf("changed tables = %+v", d.ChangedTables)
+	}
+	td := d.ChangedTables[0]
+	if len(td.AddedColumns) != 1 || len(td.AddedIndexes) != 1 || len(td.ChangedColumns) != 3 {
+		t.Errorf("users diff = %+v", td)
+	}
+	tests := []struct {
+		column                                 string
+		typeChanged, notNullChanged, defaulted bool
+	}{
+		{"name", false, true, true},
+		{"age", true, true, false},
+		{"memo", false, false, true},
+	}
+	for i, tt := range tests {
+		c := td.ChangedColumns[i]
+		if c.New.TitleReal != tt.column || c.TypeChanged != tt.typeChanged || c.NotNullChanged != tt.notNullChanged || c.DefaultChanged != tt.defaulted {
+			t.Errorf("column diff %d = %s type:%v nn:%v default:%v, want %+v", i, c.New.TitleReal, c.TypeChanged, c.NotNullChanged, c.DefaultChanged, tt)
+		}
+	}
+	if d := Diff(mustParse(t, testDiffNew), mustParse(t, testDiffNew)); !d.IsEmpty() {
+		t.Errorf("Diff of the same schema = %+v", d)
+	}
+}
+
+func TestMigration(t *testing.T) {
+	tests := []struct {
+		dialect string
+		want    []string
+	}{
+		{"pg", []string{
+			"ALTER TABLE users ALTER COLUMN name SET DEFAULT 'anon';",
+			"UPDATE users SET name = DEFAULT WHERE name IS NULL;",
+			"ALTER TABLE users ALTER COLUMN name SET NOT NULL;",
+			"ALTER TABLE users ALTER COLUMN age TYPE bigint;",
+			"ALTER TABLE users ALTER COLUMN age SET NOT NULL;",
+			"ALTER TABLE users ALTER COLUMN memo DROP DEFAULT;",
+			"COMMENT ON TABLE users IS 'site user';",
+			"CREATE INDEX i_users_tag_id ON users (tag_id);",
+			"ALTER TABLE users ADD CONSTRAINT fk_users_tag_id FOREIGN KEY (tag_id) REFERENCES tags (id);",
+		}},
+		{"mysql", []string{
+			"UPDATE `users` SET `name` = 'anon' WHERE `name` IS NULL;",
+			"ALTER TABLE `users` MODIFY COLUMN `name` text NOT NULL DEFAULT 'anon';",
+			"ALTER TABLE `users` MODIFY COLUMN `age` bigint NOT NULL;",
+			"ALTER TABLE `users` MODIFY COLUMN `memo` text;",
+			"ALTER TABLE `users` COMMENT = 'site user';",
+			"ALTER TABLE `users` ADD INDEX `i_users_tag_id` (`tag_id`);",
+		}},
+	}
+	d := Diff(mustParse(t, testDiffOld), mustParse(t, testDiffNew))
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			b := &strings.Builder{}
+			if err := d.Migration(tt.dialect, b); err != nil {
+				t.Fatal(err)
+			}
+			// 期待する文が順番どおりに出ていることを確かめる
+			rest := b.String()
+			for _, stmt := range tt.want {
+				i := strings.Index(rest, stmt)
+				if i < 0 {
+					t.Fatalf("%q is missing or out of order in\n%s", stmt, b.String())
+				}
+				rest = rest[i+len(stmt):]
+			}
+		})
+	}
+}
+
+func TestMigrationAddNotNullColumn(t *testing.T) {
+	// 既存の行があるテーブルに NOT NULL の列をそのまま追加するとエラーになる
+	o := mustParse(t, "# Title: t\n\nusers\n    +id [bigint][NN]\n")
+	n := mustParse(t, "# Title: t\n\nusers\n    +id [bigint][NN]\n    code [text][NN]\n    active [bool][NN][=true]\n")
+	tests := []struct {
+		dialect string
+		want    []string
+	}{
+		{"pg", []string{
+			"ALTER TABLE users ADD COLUMN code text;\n",
+			"-- fill the existing rows before SET NOT NULL: UPDATE users SET code = ... WHERE code IS NULL;\n",
+			"ALTER TABLE users ALTER COLUMN code SET NOT NULL;\n",
+			"ALTER TABLE users ADD COLUMN active boolean NOT NULL DEFAULT true;\n",
+		}},
+		{"mysql", []string{
+			"ALTER TABLE `users` ADD COLUMN `code` text;\n",
+			"-- fill the existing rows before NOT NULL: UPDATE `users` SET `code` = ... WHERE `code` IS NULL;\n",
+			"ALTER TABLE `users` MODIFY COLUMN `code` text NOT NULL;\n",
+			"ALTER TABLE `users` ADD COLUMN `active` tinyint(1) NOT NULL DEFAULT true;\n",
+		}},
+	}
+	d := Diff(o, n)
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			b := &strings.Builder{}
+			if err := d.Migration(tt.dialect, b); err != nil {
+				t.Fatal(err)
+			}
+			assertInOrder(t, b.String(), tt.want)
+		})
+	}
+}
+
+func TestMigrationEmpty(t *testing.T) {
+	s := mustParse(t, testDiffOld)
+	b := &strings.Builder{}
+	if err := Diff(s, s).Migration("pg", b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "-- no schema changes\n" {
+		t.Errorf("Migration = %q", b.String())
+	}
+}
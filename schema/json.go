@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// json 出力: 解析した Schema をほかのツールから読めるようにする。
+// 形を変えるときは jsonVersion を上げ、README の説明も直す。
+
+const jsonVersion = 1
+
+type jsonErdM struct {
+	Version int         `json:"version"`
+	Title   string      `json:"title"`
+	Tables  []jsonTable `json:"tables"`
+}
+
+type jsonTable struct {
+	Name        string                  `json:"name"`
+	LogicalName string                  `json:"logical_name"`
+	Comments    []string                `json:"comments"`
+	PrimaryKey  []string                `json:"primary_key"`
+	Columns     []jsonColumn            `json:"columns"`
+	Relations   []jsonCompositeRelation `json:"relations"`
+	Indexes     []jsonIndex             `json:"indexes"`
+	ForeignKeys []jsonForeignKey        `json:"foreign_keys"`
+}
+
+type jsonColumn struct {
+	Name         string            `json:"name"`
+	LogicalName  string            `json:"logical_name"`
+	Type         string            `json:"type"`
+	DialectTypes map[string]string `json:"dialect_types"`
+	NotNull      bool              `json:"not_null"`
+	Unique       bool              `json:"unique"`
+	PrimaryKey   bool              `json:"primary_key"`
+	Default      *string           `json:"default"`
+	Comments     []string          `json:"comments"`
+	WithoutErd   bool              `json:"without_erd"`
+	Relation     *jsonRelation     `json:"relation"`
+}
+
+type jsonRelation struct {
+	Table                  string `json:"table"`
+	Column                 string `json:"column"`
+	SourceCardinality      string `json:"source_cardinality"`
+	DestinationCardinality string `json:"destination_cardinality"`
+}
+
+// jsonCompositeRelation は fk (...) で書いた複数カラムのリレーション。
+type jsonCompositeRelation struct {
+	Columns                []string `json:"columns"`
+	Table                  string   `json:"table"`
+	ReferencedColumns      []string `json:"referenced_columns"`
+	SourceCardinality      string   `json:"source_cardinality"`
+	DestinationCardinality string   `json:"destination_cardinality"`
+}
+
+type jsonIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+}
+
+type jsonForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+}
+
+// emptyIfNil は null ではなく [] を出力するために使う。
+func emptyIfNil(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
+
+func newJSONColumn(c *Column) jsonColumn {
+	jc := jsonColumn{
+		Name:        c.TitleReal,
+		LogicalName: c.Title,
+		Type:        c.Type,
+		NotNull:     !c.AllowNull,
+		Unique:      c.IsUnique,
+		PrimaryKey:  c.IsPrimaryKey,
+		Comments:    emptyIfNil(c.Comments),
+		WithoutErd:  c.WithoutErd,
+	}
+	jc.DialectTypes = map[string]string{}
+	for _, d := range dialects {
+		if t, ok := c.Canonical.mapTo(d); ok {
+			jc.DialectTypes[d] = t
+		}
+	}
+	if c.HasDefaultSetting() {
+		d := c.Default
+		jc.Default = &d
+	}
+	if c.HasRelation() {
+		jc.Relation = &jsonRelation{
+			Table:                  c.Relation.TableNameReal,
+			Column:                 c.Relation.ColumnNameReal,
+			SourceCardinality:      c.Relation.CardinalitySource,
+			DestinationCardinality: c.Relation.CardinalityDestination,
+		}
+	}
+	return jc
+}
+
+func newJSONErdM(e *Schema) *jsonErdM {
+	je := &jsonErdM{Version: jsonVersion, Title: e.Title, Tables: []jsonTable{}}
+	for ti := range e.Tables {
+		t := &e.Tables[ti]
+		jt := jsonTable{
+			Name:        t.TitleReal,
+			LogicalName: t.Title,
+			Comments:    emptyIfNil(t.Comments),
+			PrimaryKey:  emptyIfNil(t.GetPrimaryKeyColumnNames()),
+			Columns:     []jsonColumn{},
+			Relations:   []jsonCompositeRelation{},
+			Indexes:     []jsonIndex{},
+			ForeignKeys: []jsonForeignKey{},
+		}
+		for ci := range t.Columns {
+			jt.Columns = append(jt.Columns, newJSONColumn(&t.Columns[ci]))
+		}
+		for _, r := range t.CompositeRelations {
+			jt.Relations = append(jt.Relations, jsonCompositeRelation{
+				Columns:                emptyIfNil(r.Columns),
+				Table:                  r.TableNameReal,
+				ReferencedColumns:      emptyIfNil(r.ReferenceColumns),
+				SourceCardinality:      r.CardinalitySource,
+				DestinationCardinality: r.CardinalityDestination,
+			})
+		}
+		for _, i := range t.Indexes {
+			jt.Indexes = append(jt.Indexes, jsonIndex{Name: i.Title, Columns: emptyIfNil(i.Columns), Unique: i.IsUnique})
+		}
+		for _, f := range t.ForeignKeys {
+			jt.ForeignKeys = append(jt.ForeignKeys, jsonForeignKey{Name: f.Name, Columns: f.Columns, ReferencedTable: f.TableNameReal, ReferencedColumns: f.ReferenceColumns})
+		}
+		je.Tables = append(je.Tables, jt)
+	}
+	return je
+}
+
+// writeJSON は Schema を JSON で書き出す。
+func writeJSON(w io.Writer, e *Schema) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(newJSONErdM(e))
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	out := mustRender(t, "json", mustParse(t, testErdMSource))
+	je := &jsonErdM{}
+	if err := json.Unmarshal([]byte(out), je); err != nil {
+		t.Fatalf("json output is not valid: %v\n%s", err, out)
+	}
+	if je.Version != jsonVersion || je.Title != "ER Sample" || len(je.Tables) != 4 {
+		t.Fatalf("json = %+v", je)
+	}
+
+	users := je.Tables[0]
+	if users.Name != "users" || users.LogicalName != "site user master" || !reflect.DeepEqual(users.Comments, []string{"registered members"}) {
+		t.Errorf("users = %+v", users)
+	}
+	id := users.Columns[0]
+	if id.Type != "bigserial" || !id.NotNull || !id.Unique || !id.PrimaryKey || id.Default != nil || id.Relation != nil {
+		t.Errorf("users.id = %+v", id)
+	}
+	if id.DialectTypes["pg"] != "bigserial" || id.DialectTypes["mysql"] != "bigint" {
+		t.Errorf("users.id dialect types = %v", id.DialectTypes)
+	}
+	if created := users.Columns[3]; created.Default == nil || *created.Default != "now()" {
+		t.Errorf("users.created = %+v", created)
+	}
+
+	tenantUsers := je.Tables[2]
+	if !reflect.DeepEqual(tenantUsers.PrimaryKey, []string{"tenant_id", "user_id"}) {
+		t.Errorf("tenant_users primary key = %v", tenantUsers.PrimaryKey)
+	}
+	// 参照先のカラムを省いたときは書いたとおり空にする
+	want := &jsonRelation{Table: "tenants", Column: "", SourceCardinality: "0..*", DestinationCardinality: "1"}
+	if r := tenantUsers.Columns[0].Relation; !reflect.DeepEqual(r, want) {
+		t.Errorf("tenant_users.tenant_id relation = %+v, want %+v", r, want)
+	}
+
+	articles := je.Tables[3]
+	wantRelations := []jsonCompositeRelation{{
+		Columns:                []string{"tenant_id", "owner_user_id"},
+		Table:                  "tenant_users",
+		ReferencedColumns:      []string{},
+		SourceCardinality:      "0..*",
+		DestinationCardinality: "0..1",
+	}}
+	if !reflect.DeepEqual(articles.Relations, wantRelations) {
+		t.Errorf("articles relations = %+v", articles.Relations)
+	}
+	wantIndexes := []jsonIndex{
+		{Name: "i_articles_owner", Columns: []string{"owner_user_id"}},
+		{Name: "i_articles_title", Columns: []string{"title", "id"}, Unique: true},
+	}
+	if !reflect.DeepEqual(articles.Indexes, wantIndexes) {
+		t.Errorf("articles indexes = %+v", articles.Indexes)
+	}
+	if len(articles.ForeignKeys) != 2 || !reflect.DeepEqual(articles.ForeignKeys[1].ReferencedColumns, []string{"tenant_id", "user_id"}) {
+		t.Errorf("articles foreign keys = %+v", articles.ForeignKeys)
+	}
+}
+
+func TestJSONEmptyLists(t *testing.T) {
+	// 空のリストは null ではなく [] にする
+	out := mustRender(t, "json", mustParse(t, "# Title: t\n\nusers\n    +id [int][NN]\n"))
+	for _, key := range []string{`"comments": []`, `"relations": []`, `"indexes": []`, `"foreign_keys": []`} {
+		if !strings.Contains(out, key) {
+			t.Errorf("%s is missing in\n%s", key, out)
+		}
+	}
+	if strings.Contains(out, `"comments": null`) {
+		t.Errorf("json has null lists:\n%s", out)
+	}
+}
@@ -0,0 +1,272 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// lint: チームの命名規約などを確かめる。どのルールを使うか、重大度は LintConfig（プロジェクトの設定ファイル）で決める。
+//
+// .erdm の中では次のコメントで個別に抑止できる。ルール名を省くとすべてのルールを抑止する。
+//
+//	// erdm-lint:disable plural-table      (テーブルの直前の行。そのテーブルとカラム・インデックスに効く)
+//	    // erdm-lint:disable index-name    (カラム・インデックス・fk の直前の行。その行に効く)
+//	    # erdm-lint:disable logical-name   (カラムのコメント行。そのカラムに効く)
+
+const lintDirective = "erdm-lint:disable"
+
+// parseLintDirective は "erdm-lint:disable rule1, rule2" から抑止するルール名を取り出す。
+func parseLintDirective(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, lintDirective) {
+		return nil, false
+	}
+	rest := s[len(lintDirective):]
+	if len(rest) > 0 && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+	rules := strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(rules) == 0 {
+		rules = []string{"all"}
+	}
+	return rules, true
+}
+
+func lintDisabled(disabled []string, rule string) bool {
+	for _, d := range disabled {
+		if d == rule || d == "all" {
+			return true
+		}
+	}
+	return false
+}
+
+// LintConfig は lint の設定ファイル（.erdm-lint.json）の内容。
+//
+//	{
+//	  "rules": {
+//	    "plural-table": {"severity": "off"},
+//	    "timestamps": {"severity": "error", "columns": ["created_at", "updated_at"]}
+//	  }
+//	}
+//
+// 書かれていないルールは警告になる。
+type LintConfig struct {
+	Rules map[string]LintRuleConfig `json:"rules"`
+}
+
+type LintRuleConfig struct {
+	// Severity は "error", "warning", "off" のいずれか
+	Severity string `json:"severity"`
+	// Columns は timestamps ルールで必須にするカラム
+	Columns []string `json:"columns,omitempty"`
+}
+
+// LoadLintConfig は設定ファイルを読み込み、ルール名と重大度を確かめる。
+func LoadLintConfig(filename string) (*LintConfig, error) {
+	config := &LintConfig{}
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(bs, config); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	for name, rc := range config.Rules {
+		if findLintRule(name) == nil {
+			return nil, fmt.Errorf("%s: unknown rule %q", filename, name)
+		}
+		switch rc.Severity {
+		case "", "error", "warning", "off":
+		default:
+			return nil, fmt.Errorf("%s: severity of %s must be error, warning or off", filename, name)
+		}
+	}
+	return config, nil
+}
+
+func (c *LintConfig) rule(name string) LintRuleConfig {
+	rc := c.Rules[name]
+	if len(rc.Severity) == 0 {
+		rc.Severity = "warning"
+	}
+	return rc
+}
+
+// LintRule は 1 つの規約。check はテーブルごとに呼ばれ、違反を linter.report に渡す。
+type LintRule struct {
+	Name        string
+	Description string
+	check       func(l *linter, t *Table, rc LintRuleConfig)
+}
+
+var lintRules = []LintRule{
+	{"snake-case", "table, column and index names are lower snake_case", lintSnakeCase},
+	{"index-name", "indexes are named i_<table>_<columns>", lintIndexName},
+	{"timestamps", "every table has the created and updated columns (\"columns\" to change them)", lintTimestamps},
+	{"fk-index", "every column with a relation is the first column of an index (the columns of fk (...) are the first columns)", lintForeignKeyIndex},
+	{"logical-name", "every table and column has a logical name", lintLogicalName},
+	{"plural-table", "table names are plural", lintPluralTable},
+}
+
+// LintRules はすべてのルールを返す。
+func LintRules() []LintRule {
+	return append([]LintRule{}, lintRules...)
+}
+
+func findLintRule(name string) *LintRule {
+	for i := range lintRules {
+		if lintRules[i].Name == name {
+			return &lintRules[i]
+		}
+	}
+	return nil
+}
+
+type linter struct {
+	rule        *LintRule
+	severity    Severity
+	diagnostics []Diagnostic
+}
+
+// report は違反を記録する。テーブルか、違反のあったカラム・インデックス・fk の disabled でルールが抑止されていれば何もしない。
+func (l *linter) report(t *Table, disabled []string, pos Position, format string, a ...interface{}) {
+	if lintDisabled(t.LintDisable, l.rule.Name) || lintDisabled(disabled, l.rule.Name) {
+		return
+	}
+	d := newDiagnostic(l.severity, pos, format, a...)
+	d.Rule = l.rule.Name
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+// Lint は config で有効なルールを全テーブルに適用する。
+func (e *Schema) Lint(config *LintConfig) []Diagnostic {
+	l := &linter{}
+	for ri := range lintRules {
+		rc := config.rule(lintRules[ri].Name)
+		if rc.Severity == "off" {
+			continue
+		}
+		l.rule = &lintRules[ri]
+		l.severity = SeverityWarning
+		if rc.Severity == "error" {
+			l.severity = SeverityError
+		}
+		for ti := range e.Tables {
+			l.rule.check(l, &e.Tables[ti], rc)
+		}
+	}
+	sortDiagnostics(l.diagnostics)
+	return l.diagnostics
+}
+
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+func lintSnakeCase(l *linter, t *Table, rc LintRuleConfig) {
+	if !snakeCasePattern.MatchString(t.TitleReal) {
+		l.report(t, nil, t.Position, "table name %s is not snake_case", t.TitleReal)
+	}
+	for ci := range t.Columns {
+		c := &t.Columns[ci]
+		if !snakeCasePattern.MatchString(c.TitleReal) {
+			l.report(t, c.LintDisable, c.Position, "column name %s.%s is not snake_case", t.TitleReal, c.TitleReal)
+		}
+	}
+	for _, i := range t.Indexes {
+		if !snakeCasePattern.MatchString(i.Title) {
+			l.report(t, i.LintDisable, i.Position, "index name %s is not snake_case", i.Title)
+		}
+	}
+}
+
+func lintIndexName(l *linter, t *Table, rc LintRuleConfig) {
+	for _, i := range t.Indexes {
+		name := "i_" + t.TitleReal + "_" + strings.Join(i.Columns, "_")
+		if i.Title != name {
+			l.report(t, i.LintDisable, i.Position, "index %s should be named %s", i.Title, name)
+		}
+	}
+}
+
+func lintTimestamps(l *linter, t *Table, rc LintRuleConfig) {
+	columns := rc.Columns
+	if len(columns) == 0 {
+		columns = []string{"created", "updated"}
+	}
+	for _, name := range columns {
+		if _, err := t.getColumnIndex(name); err != nil {
+			l.report(t, nil, t.Position, "table %s has no %s column", t.TitleReal, name)
+		}
+	}
+}
+
+func lintForeignKeyIndex(l *linter, t *Table, rc LintRuleConfig) {
+	for ci := range t.Columns {
+		c := &t.Columns[ci]
+		if !c.HasRelation() || c.IsUnique || (len(t.PrimaryKeys) > 0 && t.PrimaryKeys[0] == ci) {
+			continue
+		}
+		indexed := false
+		for _, i := range t.Indexes {
+			if len(i.Columns) > 0 && i.Columns[0] == c.TitleReal {
+				indexed = true
+			}
+		}
+		if !indexed {
+			l.report(t, c.LintDisable, c.Position, "%s.%s references %s but has no index", t.TitleReal, c.TitleReal, c.Relation.TableNameReal)
+		}
+	}
+	// fk (...) はそのカラムの組で始まるインデックス（か主キー）があればよい
+	for _, r := range t.CompositeRelations {
+		n := len(r.Columns)
+		pks := t.GetPrimaryKeyColumnNames()
+		indexed := len(pks) >= n && sameColumnSet(pks[:n], r.Columns)
+		for _, i := range t.Indexes {
+			if len(i.Columns) >= n && sameColumnSet(i.Columns[:n], r.Columns) {
+				indexed = true
+			}
+		}
+		if !indexed {
+			l.report(t, r.LintDisable, r.Position, "%s (%s) references %s but has no index", t.TitleReal, r.GetColumns(), r.TableNameReal)
+		}
+	}
+}
+
+func lintLogicalName(l *linter, t *Table, rc LintRuleConfig) {
+	if len(t.Title) == 0 {
+		l.report(t, nil, t.Position, "table %s has no logical name", t.TitleReal)
+	}
+	for ci := range t.Columns {
+		c := &t.Columns[ci]
+		if len(c.Title) == 0 {
+			l.report(t, c.LintDisable, c.Position, "column %s.%s has no logical name", t.TitleReal, c.TitleReal)
+		}
+	}
+}
+
+// irregularPlurals は s で終わらない複数形。
+var irregularPlurals = map[string]bool{
+	"people": true, "children": true, "men": true, "women": true, "data": true, "media": true,
+	"criteria": true, "feet": true, "teeth": true, "mice": true, "geese": true,
+}
+
+// isPlural は英単語 w が複数形らしいかを返す。status や address のような s で終わる単数形は除く。
+func isPlural(w string) bool {
+	w = strings.ToLower(w)
+	if irregularPlurals[w] {
+		return true
+	}
+	return strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is")
+}
+
+func lintPluralTable(l *linter, t *Table, rc LintRuleConfig) {
+	words := strings.Split(t.TitleReal, "_")
+	if !isPlural(words[len(words)-1]) {
+		l.report(t, nil, t.Position, "table name %s is not plural", t.TitleReal)
+	}
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestLintDirectives(t *testing.T) {
+	config := &LintConfig{Rules: map[string]LintRuleConfig{
+		"timestamps":   {Severity: "off"},
+		"logical-name": {Severity: "off"},
+	}}
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			"no directive",
+			"# Title: t\n\nuser\n    +id [bigint][NN]\n    index idx (id)\n",
+			[]string{"3:1: warning: table name user is not plural [plural-table]", "5:11: warning: index idx should be named i_user_id [index-name]"},
+		},
+		{
+			"table",
+			"# Title: t\n\n// erdm-lint:disable\nuser\n    +id [bigint][NN]\n    index idx (id)\n",
+			nil,
+		},
+		{
+			"column",
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    // erdm-lint:disable snake-case\n    userName [text]\n    ownerName [text]\n        # erdm-lint:disable snake-case\n    groupName [text]\n",
+			[]string{"9:5: warning: column name users.groupName is not snake_case [snake-case]"},
+		},
+		{
+			"index",
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    name [text]\n    // erdm-lint:disable index-name\n    index idx_a (id)\n    index idx_b (name)\n",
+			[]string{"8:11: warning: index idx_b should be named i_users_name [index-name]"},
+		},
+		{
+			"fk",
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    a [bigint]\n    b [bigint]\n    // erdm-lint:disable fk-index\n    fk (a, b) 0..*--1 pairs\n    fk (b, a) 0..*--1 pairs\n\npairs\n    +a [bigint][NN]\n    +b [bigint][NN]\n",
+			[]string{"9:5: warning: users (b, a) references pairs but has no index [fk-index]"},
+		},
+		{
+			"index directive does not leak to the next table",
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    // erdm-lint:disable plural-table\n    index i_users_id (id)\n\nuser\n    +id [bigint][NN]\n",
+			[]string{"8:1: warning: table name user is not plural [plural-table]"},
+		},
+		{
+			"fk directive does not leak to the next table",
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    // erdm-lint:disable snake-case\n    fk (id) 0..*--1 users\n\nuserGroups\n    +id [bigint][NN]\n",
+			[]string{"8:1: warning: table name userGroups is not snake_case [snake-case]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustParse(t, tt.src)
+			got := []string{}
+			for _, d := range s.Lint(config) {
+				got = append(got, d.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Lint =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLoadLintConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"unknown rule", `{"rules": {"no-such-rule": {}}}`, `unknown rule "no-such-rule"`},
+		{"severity", `{"rules": {"plural-table": {"severity": "info"}}}`, "severity of plural-table must be error, warning or off"},
+		{"json", `{"rules": `, "unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := t.TempDir() + "/.erdm-lint.json"
+			if err := ioutil.WriteFile(filename, []byte(tt.json), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadLintConfig(filename); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadLintConfig error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// lintOnly は rule だけを有効にした設定を返す。
+func lintOnly(rule string, rc LintRuleConfig) *LintConfig {
+	config := &LintConfig{Rules: map[string]LintRuleConfig{}}
+	for _, r := range LintRules() {
+		config.Rules[r.Name] = LintRuleConfig{Severity: "off"}
+	}
+	config.Rules[rule] = rc
+	return config
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		rc   LintRuleConfig
+		src  string
+		want []string
+	}{
+		{
+			"snake-case", "snake-case", LintRuleConfig{},
+			"# Title: t\n\nUserGroups\n    +id [bigint][NN]\n    groupName [text]\n    user_id2 [bigint]\n    index IUser (id)\n",
+			[]string{
+				"3:1: warning: table name UserGroups is not snake_case [snake-case]",
+				"5:5: warning: column name UserGroups.groupName is not snake_case [snake-case]",
+				"7:11: warning: index name IUser is not snake_case [snake-case]",
+			},
+		},
+		{
+			"index-name", "index-name", LintRuleConfig{},
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    a [int]\n    b [int]\n    index i_users_a_b (a, b)\n    index i_users_b (a)\n",
+			[]string{"8:11: warning: index i_users_b should be named i_users_a [index-name]"},
+		},
+		{
+			"timestamps", "timestamps", LintRuleConfig{},
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    created [timestamp]\n",
+			[]string{"3:1: warning: table users has no updated column [timestamps]"},
+		},
+		{
+			"timestamps with columns", "timestamps", LintRuleConfig{Columns: []string{"created_at", "updated_at"}},
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n    created [timestamp]\n    updated_at [timestamp]\n",
+			[]string{"3:1: warning: table users has no created_at column [timestamps]"},
+		},
+		{
+			"fk-index", "fk-index", LintRuleConfig{},
+			"# Title: t\n\ngroups\n    +id [bigint][NN]\n\nusers\n    +id [bigint][NN]\n    group_id [bigint] 0..*--1 groups\n    owner_id [bigint] 0..*--1 groups\n    leader_id [bigint][U] 0..1--1 groups\n    index i_users_owner_id (owner_id)\n",
+			[]string{"8:5: warning: users.group_id references groups but has no index [fk-index]"},
+		},
+		{
+			"fk-index on the primary key", "fk-index", LintRuleConfig{},
+			"# Title: t\n\nusers\n    +id [bigint][NN]\n\nprofiles\n    +user_id [bigint][NN] 0..1--1 users\n",
+			nil,
+		},
+		{
+			"logical-name", "logical-name", LintRuleConfig{},
+			"# Title: t\n\nusers/\"user\"\n    +id [bigint][NN]\n    name/\"name\" [text]\n\ngroups\n    +id/\"id\" [bigint][NN]\n",
+			[]string{
+				"4:6: warning: column users.id has no logical name [logical-name]",
+				"7:1: warning: table groups has no logical name [logical-name]",
+			},
+		},
+		{
+			"plural-table", "plural-table", LintRuleConfig{},
+			"# Title: t\n\nuser_status\n    +id [int][NN]\n\npeople\n    +id [int][NN]\n\nuser_addresses\n    +id [int][NN]\n\nbus\n    +id [int][NN]\n",
+			[]string{
+				"3:1: warning: table name user_status is not plural [plural-table]",
+				"12:1: warning: table name bus is not plural [plural-table]",
+			},
+		},
+		{
+			"severity error", "plural-table", LintRuleConfig{Severity: "error"},
+			"# Title: t\n\nuser\n    +id [int][NN]\n",
+			[]string{"3:1: error: table name user is not plural [plural-table]"},
+		},
+		{
+			"severity off", "plural-table", LintRuleConfig{Severity: "off"},
+			"# Title: t\n\nuser\n    +id [int][NN]\n",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, d := range mustParse(t, tt.src).Lint(lintOnly(tt.rule, tt.rc)) {
+				got = append(got, d.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Lint =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIsPlural(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"users", true},
+		{"Users", true},
+		{"people", true},
+		{"data", true},
+		{"user", false},
+		{"address", false},
+		{"status", false},
+		{"analysis", false},
+	}
+	for _, tt := range tests {
+		if got := isPlural(tt.word); got != tt.want {
+			t.Errorf("isPlural(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
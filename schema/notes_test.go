@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testNotesSource = `# Title: notes
+
+// top
+// ----- master -----
+
+users
+    +id   [int][NN]
+    // contact
+
+    // e-mail
+    email [text]
+
+    // lookup
+    index i_users_email (email)
+
+// groups of users
+
+groups
+    +id      [int][NN]
+    owner_id [int]
+    // owner
+    fk (owner_id, id) 0..*--1 users (id, id)
+// trailing
+`
+
+// noteTexts は notes の GetText を / でつなぐ。空行の後のものには ^ を付ける。
+func noteTexts(notes []Comment) string {
+	ts := []string{}
+	for _, n := range notes {
+		s := n.GetText()
+		if n.BlankLineBefore {
+			s = "^" + s
+		}
+		ts = append(ts, s)
+	}
+	return strings.Join(ts, "/")
+}
+
+func TestNotes(t *testing.T) {
+	s := mustParse(t, testNotesSource)
+	users, groups := s.Tables[0], s.Tables[1]
+	tests := []struct {
+		name  string
+		notes []Comment
+		want  string
+	}{
+		{"table", users.Notes, "^top/----- master -----"},
+		{"column", users.Columns[1].Notes, "contact/^e-mail"},
+		{"index", users.Indexes[0].Notes, "^lookup"},
+		{"table after a blank line", groups.Notes, "^groups of users"},
+		{"fk", groups.CompositeRelations[0].Notes, "owner"},
+		{"end of file", s.Notes, "trailing"},
+	}
+	for _, tt := range tests {
+		if got := noteTexts(tt.notes); got != tt.want {
+			t.Errorf("%s notes = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+	// BlankLineBefore はコメントとその後ろの行の間の空行
+	if !users.BlankLineBefore || !groups.BlankLineBefore || users.Columns[1].BlankLineBefore {
+		t.Errorf("blank lines = %v %v %v", users.BlankLineBefore, groups.BlankLineBefore, users.Columns[1].BlankLineBefore)
+	}
+	if n := users.Columns[1].Notes[1]; n.Position.Line != 10 || n.Position.Column != 7 {
+		t.Errorf("position of // e-mail = %+v", n.Position)
+	}
+}
+
+func TestNotesAreWrittenBack(t *testing.T) {
+	if got := mustRender(t, "erdm", mustParse(t, testNotesSource)); got != testNotesSource {
+		t.Errorf("erdm =\n%s\nwant\n%s", got, testNotesSource)
+	}
+}
+
+func TestHTMLNotes(t *testing.T) {
+	out := mustRender(t, "html", mustParse(t, testNotesSource))
+	assertInOrder(t, out, []string{
+		"<div class=\"alert alert-secondary notes\">top\n----- master -----</div>",
+		"<td colspan=\"9\" class=\"text-body-secondary notes\">contact\ne-mail</td>",
+		"<td colspan=\"3\" class=\"text-body-secondary notes\">lookup</td>",
+		"<div class=\"alert alert-secondary notes\">groups of users</div>",
+		"<td colspan=\"3\" class=\"text-body-secondary notes\">owner</td>",
+		"<div class=\"alert alert-secondary notes\">trailing</div>",
+	})
+}
@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+var templateFiles = []string{
+	"templates/dot.tmpl",
+	"templates/dot_tables.tmpl",
+	"templates/dot_relations.tmpl",
+	"templates/pg_ddl.tmpl",
+	"templates/sqlite3_ddl.tmpl",
+	"templates/mysql_ddl.tmpl",
+	"templates/mssql_ddl.tmpl",
+	"templates/oracle_ddl.tmpl",
+	"templates/mermaid.tmpl",
+	"templates/plantuml.tmpl",
+	"templates/dbml.tmpl",
+	"templates/pg_migration.tmpl",
+	"templates/mysql_migration.tmpl",
+	"templates/review.tmpl",
+}
+
+var htmlTemplateFiles = []string{
+	"templates/html.tmpl",
+	"templates/changelog.tmpl",
+}
+
+// renderers は Render で指定できる形式。テンプレート名か、テンプレートを使わずに書き出す関数を持つ。
+var renderers = []struct {
+	Name     string
+	Template string
+	Write    func(w io.Writer, e *Schema) error
+}{
+	{Name: "dot", Template: "dot"},
+	{Name: "html", Template: "html"},
+	{Name: "pg", Template: "pg_ddl"},
+	{Name: "sqlite3", Template: "sqlite3_ddl"},
+	{Name: "mysql", Template: "mysql_ddl"},
+	{Name: "mssql", Template: "mssql_ddl"},
+	{Name: "oracle", Template: "oracle_ddl"},
+	{Name: "mermaid", Template: "mermaid"},
+	{Name: "plantuml", Template: "plantuml"},
+	{Name: "dbml", Template: "dbml"},
+	{Name: "json", Write: writeJSON},
+	{Name: "erdm", Write: writeErdM},
+}
+
+// Formats は Render で指定できる形式の名前を返す。
+func Formats() []string {
+	ns := []string{}
+	for _, r := range renderers {
+		ns = append(ns, r.Name)
+	}
+	return ns
+}
+
+// Dialects は DDL を書き出せる SQL の方言を返す。
+func Dialects() []string {
+	return append([]string{}, dialects...)
+}
+
+var (
+	templatesOnce sync.Once
+	textTemplates *template.Template
+	htmlTemplates *htmltemplate.Template
+	templatesErr  error
+)
+
+// loadTemplates は dot/SQL 用の text/template と html 用の html/template を読み込む。
+// dot/SQL は raw text（text/template）。html だけは context-aware に
+// HTML エスケープしたいので html/template を使う。
+func loadTemplates() (*template.Template, *htmltemplate.Template, error) {
+	templatesOnce.Do(func() {
+		textTemplates, htmlTemplates, templatesErr = parseTemplates()
+	})
+	return textTemplates, htmlTemplates, templatesErr
+}
+
+func parseTemplates() (*template.Template, *htmltemplate.Template, error) {
+	text := ""
+	for _, f := range templateFiles {
+		s, err := asset(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		text += string(s)
+	}
+	t, err := template.New("template").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	html_string := ""
+	for _, f := range htmlTemplateFiles {
+		s, err := asset(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		html_string += string(s)
+	}
+	htmlT, err := htmltemplate.New("html").Parse(html_string)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, htmlT, nil
+}
+
+// Render は s を format（"pg", "html", "json" など Formats の形式）で w に書き出す。
+// html は s.ImageFilename か s.ImageSVG があれば ER 図を埋め込む。
+func Render(format string, s *Schema, w io.Writer) error {
+	for _, r := range renderers {
+		if r.Name != format {
+			continue
+		}
+		if r.Write != nil {
+			return r.Write(w, s)
+		}
+		t, htmlT, err := loadTemplates()
+		if err != nil {
+			return err
+		}
+		if r.Name == "html" {
+			return htmlT.ExecuteTemplate(w, r.Template, s)
+		}
+		return t.ExecuteTemplate(w, r.Template, s)
+	}
+	return fmt.Errorf("unknown format: %s (available: %s)", format, strings.Join(Formats(), ","))
+}
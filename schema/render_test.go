@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRenderAllFormats(t *testing.T) {
+	s := mustParse(t, testErdMSource)
+	for _, f := range Formats() {
+		t.Run(f, func(t *testing.T) {
+			if out := mustRender(t, f, s); len(out) == 0 {
+				t.Errorf("Render(%s) wrote nothing", f)
+			}
+		})
+	}
+	// DDL を書ける方言は Render の形式でもある
+	for _, d := range Dialects() {
+		if !slices.Contains(Formats(), d) {
+			t.Errorf("dialect %s is not a format", d)
+		}
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render("png", mustParse(t, testErdMSource), &strings.Builder{})
+	if err == nil || !strings.HasPrefix(err.Error(), "unknown format: png (available: dot,html,pg,") {
+		t.Errorf("Render error = %v", err)
+	}
+}
+
+func TestParsePartial(t *testing.T) {
+	src := "# Title: x\n\nusers\n    +id [int][NN]\n\narticles\n    +id [int][NN]\n    user_id [int] 0..*--1 nope\n"
+	if s, ds := Parse(strings.NewReader(src)); s != nil || len(ds) != 1 {
+		t.Errorf("Parse = %v, %v", s, ds)
+	}
+	s, ds := ParsePartial(strings.NewReader(src))
+	if len(ds) != 1 || ds[0].Severity != SeverityError {
+		t.Errorf("ParsePartial diagnostics = %v", ds)
+	}
+	if tableNames(s.Tables) != "users articles" || len(s.Tables[1].Columns) != 2 {
+		t.Errorf("ParsePartial tables = %+v", s.Tables)
+	}
+	// 外部キーは組み立てない
+	if len(s.Tables[1].ForeignKeys) != 0 {
+		t.Errorf("ParsePartial built foreign keys: %+v", s.Tables[1].ForeignKeys)
+	}
+
+	// 構文エラーでも、エラーの手前までのテーブルは返す
+	s, ds = ParsePartial(strings.NewReader("# Title: x\n\nusers\n    +id [int][NN]\n\narticles\n    +id [int\n"))
+	if !Diagnostics(ds).HasError() || s == nil || s.Title != "x" || len(s.Tables) == 0 || s.Tables[0].TitleReal != "users" {
+		t.Errorf("ParsePartial = %+v, %v", s, ds)
+	}
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Review は erdm review が表示する、git のリビジョンと作業ツリーの間の差分の要約。
+type Review struct {
+	Filename string
+	Rev      string
+	IsNew    bool
+	Diff     *SchemaDiff
+}
+
+// GetSummary は "2 added, 1 dropped, 3 modified" のようなテーブル数の要約を返す。
+func (r *Review) GetSummary() string {
+	d := r.Diff
+	ss := []string{}
+	if len(d.AddedTables) > 0 {
+		ss = append(ss, fmt.Sprintf("%d added", len(d.AddedTables)))
+	}
+	if len(d.DroppedTables) > 0 {
+		ss = append(ss, fmt.Sprintf("%d dropped", len(d.DroppedTables)))
+	}
+	if len(d.ChangedTables) > 0 {
+		ss = append(ss, fmt.Sprintf("%d modified", len(d.ChangedTables)))
+	}
+	return strings.Join(ss, ", ")
+}
+
+// Render は要約をテキストで w に書き出す。
+func (r *Review) Render(w io.Writer) error {
+	t, _, err := loadTemplates()
+	if err != nil {
+		return err
+	}
+	return t.ExecuteTemplate(w, "review", r)
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReview(t *testing.T) {
+	tests := []struct {
+		name   string
+		review *Review
+		want   string
+	}{
+		{"changes", &Review{Filename: "schema.erdm", Rev: "HEAD", Diff: Diff(mustParse(t, testDiffOld), mustParse(t, testDiffNew))}, `schema.erdm: HEAD -> working tree
+
++ table tags
+    + +id [bigint][NN]
+- table groups
+~ table users (site user)
+    ~ logical name (none) -> site user
+    + tag_id [bigint] 0..*--1 tags
+    ~ name: [NN] added, default (none) -> 'anon'
+    ~ age: type int -> bigint, [NN] added
+    ~ memo: default 'x' -> (none)
+    + index i_users_tag_id (tag_id)
+
+tables: 1 added, 1 dropped, 1 modified
+`},
+		{"new file", &Review{Filename: "schema.erdm", Rev: "HEAD", IsNew: true, Diff: Diff(&Schema{}, mustParse(t, "# Title: t\n\nusers\n    +id [int][NN]\n"))}, `schema.erdm: HEAD -> working tree (new file)
+
++ table users
+    + +id [int][NN]
+
+tables: 1 added
+`},
+		{"no changes", &Review{Filename: "schema.erdm", Rev: "HEAD", Diff: Diff(mustParse(t, testDiffOld), mustParse(t, testDiffOld))}, `schema.erdm: HEAD -> working tree
+
+no schema changes
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &strings.Builder{}
+			if err := tt.review.Render(b); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("Render =\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
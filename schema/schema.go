@@ -0,0 +1,426 @@
+// Package schema は .erdm（と DBML）を読み込み、ER 図・DDL などの各形式に書き出す。
+// erdm コマンドはこのパッケージの薄いラッパーになっている。
+//
+//	s, ds := schema.Parse(r)
+//	if schema.Diagnostics(ds).HasError() {
+//		...
+//	}
+//	err := schema.Render("pg", s, w)
+package schema
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Comment は // のコメント行。次のテーブル・カラム・インデックスに付ける。
+type Comment struct {
+	Text     string
+	Position Position
+	// BlankLineBefore は直前に空行があること
+	BlankLineBefore bool
+}
+
+// GetText は // の後ろの空白を除いたコメントを返す。
+func (c *Comment) GetText() string {
+	return strings.TrimSpace(c.Text)
+}
+
+type TableRelation struct {
+	TableNameReal          string
+	ColumnNameReal         string
+	CardinalitySource      string
+	CardinalityDestination string
+	Position               Position
+}
+
+// CompositeRelation はテーブルに書く複数カラムのリレーション。
+//
+//	fk (tenant_id, user_id) 0..*--1 users (tenant_id, id)
+//
+// 参照先のカラムを省略すると参照先テーブルの主キーを参照する。
+type CompositeRelation struct {
+	Columns                []string
+	TableNameReal          string
+	ReferenceColumns       []string
+	CardinalitySource      string
+	CardinalityDestination string
+	Position               Position
+	// TablePosition は参照先テーブル名の位置
+	TablePosition   Position
+	LintDisable     []string
+	Notes           []Comment
+	BlankLineBefore bool
+}
+
+type Index struct {
+	Title           string
+	Columns         []string
+	IsUnique        bool
+	Position        Position
+	LintDisable     []string
+	Notes           []Comment
+	BlankLineBefore bool
+}
+
+type Column struct {
+	TitleReal    string
+	Title        string
+	Type         string
+	Canonical    CanonicalType
+	AllowNull    bool
+	IsUnique     bool
+	IsPrimaryKey bool
+	IsForeignKey bool
+	Default      string
+	Relation     TableRelation
+	Comments     []string
+	IndexIndexes []int
+	WithoutErd   bool
+	Position     Position
+	LintDisable  []string
+	// Notes はカラムの直前にある // のコメント行
+	Notes           []Comment
+	BlankLineBefore bool
+}
+
+type Table struct {
+	TitleReal   string
+	Title       string
+	Comments    []string
+	Columns     []Column
+	PrimaryKeys []int
+	Indexes     []Index
+	ForeignKeys []ForeignKey
+	// CompositeRelations は fk (...) で書いた複数カラムのリレーション
+	CompositeRelations []CompositeRelation
+	Position           Position
+	LintDisable        []string
+	// Notes はテーブルの直前にある // のコメント行
+	Notes           []Comment
+	BlankLineBefore bool
+}
+
+type Schema struct {
+	Title         string
+	Tables        []Table
+	ImageFilename string
+	ImageSVG      htmltemplate.HTML
+	// Notes は最後のテーブル・カラム・インデックスより後ろにある // のコメント行。
+	// 読み込み中はまだ付け先の決まっていないコメント行を持つ
+	Notes       []Comment
+	createOrder []int
+
+	// 読み込み中に使う。いま追加しているテーブルと、そのテーブルのカラム・インデックス・fk の添字
+	currentTableId             int
+	currentColumnId            int
+	currentIndexId             int
+	currentCompositeRelationId int
+	lintDisable                []string
+	lastLine                   int
+}
+
+func (e *Schema) setTitle(t string) {
+	e.Title = t
+}
+
+// blankLineBefore は pos の行の直前に空行があるかを返し、pos の行まで読んだことにする。
+// 最初は # Title: の 1 行目まで読んでいる。
+func (e *Schema) blankLineBefore(pos Position) bool {
+	last := e.lastLine
+	if last == 0 {
+		last = 1
+	}
+	e.lastLine = pos.Line
+	return pos.Line > last+1
+}
+
+func (e *Schema) addTableTitleReal(t string) {
+	e.Tables = append(e.Tables, Table{TitleReal: t, LintDisable: e.lintDisable, Notes: e.Notes})
+	e.currentTableId = len(e.Tables) - 1
+	e.lintDisable = nil
+	e.Notes = nil
+}
+
+func (e *Schema) addTableTitle(t string) {
+	t = strings.Trim(t, "\"")
+	e.Tables[e.currentTableId].Title = t
+}
+
+func (e *Schema) addPrimaryKey(text string) {
+	// カラムはこの直後の setColumnNameReal で追加されるので、その添字を主キーとして登録する
+	e.Tables[e.currentTableId].PrimaryKeys = append(e.Tables[e.currentTableId].PrimaryKeys, len(e.Tables[e.currentTableId].Columns))
+}
+
+// addTableComment はテーブル名の直後の # の行を読む。lint の抑止指定はそのテーブルに付ける。
+func (e *Schema) addTableComment(t string) {
+	if rules, ok := parseLintDirective(t); ok {
+		e.Tables[e.currentTableId].LintDisable = append(e.Tables[e.currentTableId].LintDisable, rules...)
+		return
+	}
+	e.Tables[e.currentTableId].Comments = append(e.Tables[e.currentTableId].Comments, t)
+}
+
+func (e *Schema) setColumnNameReal(t string) {
+	e.Tables[e.currentTableId].Columns = append(e.Tables[e.currentTableId].Columns, Column{TitleReal: t, AllowNull: true, IsUnique: false, IsForeignKey: false, WithoutErd: false, LintDisable: e.lintDisable, Notes: e.Notes})
+	e.lintDisable = nil
+	e.Notes = nil
+	e.currentColumnId = len(e.Tables[e.currentTableId].Columns) - 1
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].IsPrimaryKey = e.Tables[e.currentTableId].isPrimaryKey(e.currentColumnId)
+}
+
+func (e *Schema) setColumnName(t string) {
+	t = strings.Trim(t, "\"")
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Title = t
+}
+
+func (e *Schema) addColumnType(t string) {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Type = t
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Canonical = parseCanonicalType(t)
+}
+
+func (e *Schema) setNotNull() {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].AllowNull = false
+}
+func (e *Schema) setUnique() {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].IsUnique = true
+}
+
+func (e *Schema) setColumnDefault(t string) {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Default = t
+}
+func (e *Schema) setWithoutErd() {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].WithoutErd = true
+}
+func (e *Schema) setRelationSource(t string) {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Relation.CardinalitySource = t
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].IsForeignKey = true
+}
+
+func (e *Schema) setRelationDestination(t string) {
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Relation.CardinalityDestination = t
+}
+
+func (e *Schema) setRelationTableNameReal(t string) {
+	// relation_point は "table" または "table.column"
+	c := ""
+	if i := strings.Index(t, "."); i >= 0 {
+		t, c = t[:i], t[i+1:]
+	}
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Relation.TableNameReal = t
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Relation.ColumnNameReal = c
+}
+
+func (e *Schema) addComment(t string) {
+	if rules, ok := parseLintDirective(t); ok {
+		e.Tables[e.currentTableId].Columns[e.currentColumnId].LintDisable = append(e.Tables[e.currentTableId].Columns[e.currentColumnId].LintDisable, rules...)
+		return
+	}
+	e.Tables[e.currentTableId].Columns[e.currentColumnId].Comments = append(e.Tables[e.currentTableId].Columns[e.currentColumnId].Comments, t)
+}
+
+// addLineComment は // のコメント行を読む。コメントと lint の抑止指定は次のテーブル・カラム・インデックス・fk に付ける。
+func (e *Schema) addLineComment(t string, pos Position) {
+	blank := e.blankLineBefore(pos)
+	if rules, ok := parseLintDirective(t); ok {
+		e.lintDisable = append(e.lintDisable, rules...)
+		return
+	}
+	e.Notes = append(e.Notes, Comment{Text: t, Position: pos, BlankLineBefore: blank})
+}
+
+func (e *Schema) setIndexName(t string) {
+	e.Tables[e.currentTableId].Indexes = append(e.Tables[e.currentTableId].Indexes, Index{Title: t, IsUnique: false, LintDisable: e.lintDisable, Notes: e.Notes})
+	e.lintDisable = nil
+	e.Notes = nil
+	e.currentIndexId = len(e.Tables[e.currentTableId].Indexes) - 1
+}
+
+func (e *Schema) addCompositeRelation() {
+	e.Tables[e.currentTableId].CompositeRelations = append(e.Tables[e.currentTableId].CompositeRelations, CompositeRelation{LintDisable: e.lintDisable, Notes: e.Notes})
+	e.lintDisable = nil
+	e.Notes = nil
+	e.currentCompositeRelationId = len(e.Tables[e.currentTableId].CompositeRelations) - 1
+}
+
+func (e *Schema) currentCompositeRelation() *CompositeRelation {
+	t := &e.Tables[e.currentTableId]
+	return &t.CompositeRelations[e.currentCompositeRelationId]
+}
+
+func (e *Schema) addCompositeRelationColumn(t string) {
+	r := e.currentCompositeRelation()
+	r.Columns = append(r.Columns, t)
+	i, err := e.Tables[e.currentTableId].getColumnIndex(t)
+	if err != nil {
+		// 存在しないカラムは validate で報告する
+		return
+	}
+	e.Tables[e.currentTableId].Columns[i].IsForeignKey = true
+}
+
+func (e *Schema) setCompositeRelationSource(t string) {
+	e.currentCompositeRelation().CardinalitySource = t
+}
+
+func (e *Schema) setCompositeRelationDestination(t string) {
+	e.currentCompositeRelation().CardinalityDestination = t
+}
+
+func (e *Schema) setCompositeRelationTableNameReal(t string) {
+	e.currentCompositeRelation().TableNameReal = t
+}
+
+func (e *Schema) addCompositeRelationReferenceColumn(t string) {
+	r := e.currentCompositeRelation()
+	r.ReferenceColumns = append(r.ReferenceColumns, t)
+}
+
+func (e *Schema) setUniqueIndex() {
+	e.Tables[e.currentTableId].Indexes[e.currentIndexId].IsUnique = true
+}
+
+func (e *Schema) setIndexColumn(t string) {
+	e.Tables[e.currentTableId].Indexes[e.currentIndexId].Columns = append(e.Tables[e.currentTableId].Indexes[e.currentIndexId].Columns, t)
+	i, err := e.Tables[e.currentTableId].getColumnIndex(t)
+	if err != nil {
+		// 存在しないカラムは validate で報告する
+		return
+	}
+	e.Tables[e.currentTableId].Columns[i].IndexIndexes = append(e.Tables[e.currentTableId].Columns[i].IndexIndexes, e.currentIndexId)
+}
+
+// addPrimaryKeyColumn は定義済みのカラムを後から主キーにする。
+func (t *Table) addPrimaryKeyColumn(index int) {
+	if !t.isPrimaryKey(index) {
+		t.PrimaryKeys = append(t.PrimaryKeys, index)
+	}
+	t.Columns[index].IsPrimaryKey = true
+}
+
+func (t *Table) getColumnIndex(s string) (int, error) {
+	for i, v := range t.Columns {
+		if v.TitleReal == s {
+			return i, nil
+		}
+	}
+	return -1, os.ErrInvalid
+}
+
+func in_array(val interface{}, array interface{}) (exists bool) {
+	exists = false
+
+	switch reflect.TypeOf(array).Kind() {
+	case reflect.Slice:
+		s := reflect.ValueOf(array)
+
+		for i := 0; i < s.Len(); i++ {
+			if reflect.DeepEqual(val, s.Index(i).Interface()) == true {
+				exists = true
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (t *Table) isPrimaryKey(index int) bool {
+	return in_array(index, t.PrimaryKeys)
+}
+
+func (c *Column) HasDefaultSetting() bool {
+	return len(c.Default) > 0
+}
+
+func (c *Column) HasRelation() bool {
+	return len(c.Relation.TableNameReal) > 0
+}
+
+func (c *Column) HasComment() bool {
+	return len(c.Comments) > 0
+}
+
+// GetDescription は論理名とコメント行を改行でつないだ説明文を返す。
+func (c *Column) GetDescription() string {
+	ds := []string{}
+	if len(c.Title) > 0 {
+		ds = append(ds, c.Title)
+	}
+	ds = append(ds, c.Comments...)
+	return strings.Join(ds, "\n")
+}
+
+func (t *Table) HasComment() bool {
+	return len(t.Comments) > 0
+}
+
+// GetDescription は論理名と説明の行を改行でつないだ説明文を返す。
+func (t *Table) GetDescription() string {
+	ds := []string{}
+	if len(t.Title) > 0 {
+		ds = append(ds, t.Title)
+	}
+	ds = append(ds, t.Comments...)
+	return strings.Join(ds, "\n")
+}
+
+func (t *Table) GetPrimaryKeyColumnNames() []string {
+	ps := []string{}
+	for _, pk := range t.PrimaryKeys {
+		ps = append(ps, t.Columns[pk].TitleReal)
+	}
+	return ps
+}
+
+func (t *Table) GetPrimaryKeyColumns() string {
+	return strings.Join(t.GetPrimaryKeyColumnNames(), ", ")
+}
+
+func (i *Index) GetIndexColumns() string {
+	return strings.Join(i.Columns, ", ")
+}
+
+// Parse は .erdm を読み込み、定義を確かめてから外部キーまで組み立てる。
+// 構文エラーか定義のエラーがあれば Schema は nil になる。警告だけなら Schema と一緒に返す。
+func Parse(r io.Reader) (*Schema, []Diagnostic) {
+	e, ds := ParsePartial(r)
+	if Diagnostics(ds).HasError() {
+		return nil, ds
+	}
+	e.buildForeignKeys()
+	return e, ds
+}
+
+// ParsePartial は Parse と同じく読み込んで確かめるが、エラーがあっても読めたところまでの Schema を返す。
+// 外部キーは組み立てない。書きかけの .erdm を扱うエディタ向け。
+func ParsePartial(r io.Reader) (*Schema, []Diagnostic) {
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return &Schema{}, []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+	p := &parser{Buffer: string(bs)}
+	p.Init()
+	if err = p.Parse(); err != nil {
+		return &Schema{}, []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+	p.Execute()
+	if len(p.diagnostics) > 0 {
+		return &p.Schema, p.diagnostics
+	}
+	return &p.Schema, p.Schema.validate()
+}
+
+// build は validate で定義を確かめ、エラーがなければ外部キーを組み立てる。
+func (e *Schema) build() (*Schema, []Diagnostic) {
+	ds := Diagnostics(e.validate())
+	if ds.HasError() {
+		return nil, ds
+	}
+	e.buildForeignKeys()
+	return e, ds
+}
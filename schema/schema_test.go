@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+// testErdMSource はテストで共通に使う .erdm。
+const testErdMSource = `# Title: ER Sample
+
+// ----- master tables -----
+
+users/"site user master"
+    # registered members
+    +id/"member id" [bigserial][NN][U]
+    nick_name/nickname [varchar(128)][NN]
+
+    // login
+    password/"site password" [varchar(128)]
+        # hashed
+    created [timestamp][NN][=now()]
+
+tenants/tenant
+    +id [bigint][NN]
+
+tenant_users/"tenant user"
+    +tenant_id [bigint][NN] 0..*--1 tenants
+    +user_id [bigint][NN] 0..*--1 users
+
+articles/article
+    +id/"article id" [bigserial][NN][U]
+    title/"article title" [varchar(256)][NN]
+    owner_user_id/creator [bigint][NN] 0..*--1 users
+    tenant_id [bigint]
+    fk (tenant_id, owner_user_id) 0..*--0..1 tenant_users
+    index i_articles_owner (owner_user_id)
+    index i_articles_title (title, id) unique
+`
+
+func mustParse(t *testing.T, src string) *Schema {
+	t.Helper()
+	s, ds := Parse(strings.NewReader(src))
+	if s == nil {
+		t.Fatalf("Parse failed: %v", ds)
+	}
+	return s
+}
+
+// mustRender は s を format で書き出した文字列を返す。
+func mustRender(t *testing.T, format string, s *Schema) string {
+	t.Helper()
+	b := &strings.Builder{}
+	if err := Render(format, s, b); err != nil {
+		t.Fatalf("Render(%s): %v", format, err)
+	}
+	return b.String()
+}
+
+// assertInOrder は want の各行が out にこの順で現れることを確かめる。
+// pg_ddl.tmpl などは改行が CRLF なので LF にそろえてから比べる。
+func assertInOrder(t *testing.T, out string, want []string) {
+	t.Helper()
+	out = strings.Replace(out, "\r\n", "\n", -1)
+	rest := out
+	for _, w := range want {
+		i := strings.Index(rest, w)
+		if i < 0 {
+			t.Fatalf("%q is missing or out of order in\n%s", w, out)
+		}
+		rest = rest[i+len(w):]
+	}
+}
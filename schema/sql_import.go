@@ -0,0 +1,736 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// import-sql: pg_dump --schema-only などの PostgreSQL の DDL から .erdm を作る。
+
+type sqlToken struct {
+	kind       string // ident, qident, string, number, symbol
+	text       string
+	start, end int
+}
+
+func (t sqlToken) is(kw string) bool {
+	return t.kind == "ident" && strings.EqualFold(t.text, kw)
+}
+
+// tokenizeSQL は DDL をトークンに分け、; ごとの文にまとめる。コメントは読み捨てる。
+func tokenizeSQL(src string) ([][]sqlToken, error) {
+	stmts := [][]sqlToken{}
+	ts := []sqlToken{}
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			for i += 2; i+1 < len(rs) && !(rs[i] == '*' && rs[i+1] == '/'); i++ {
+			}
+			i += 2
+			continue
+		case c == ';':
+			if len(ts) > 0 {
+				stmts = append(stmts, ts)
+			}
+			ts = []sqlToken{}
+			i++
+			continue
+		case c == '\'' || ((c == 'E' || c == 'e') && i+1 < len(rs) && rs[i+1] == '\''):
+			escape := c != '\''
+			if escape {
+				i++
+			}
+			b := []rune{}
+			for i++; ; i++ {
+				if i >= len(rs) {
+					return nil, fmt.Errorf("unterminated string at offset %d", start)
+				}
+				if escape && rs[i] == '\\' && i+1 < len(rs) {
+					i++
+					switch rs[i] {
+					case 'n':
+						b = append(b, '\n')
+					case 'r':
+						b = append(b, '\r')
+					case 't':
+						b = append(b, '\t')
+					default:
+						b = append(b, rs[i])
+					}
+					continue
+				}
+				if rs[i] == '\'' {
+					if i+1 < len(rs) && rs[i+1] == '\'' {
+						b = append(b, '\'')
+						i++
+						continue
+					}
+					break
+				}
+				b = append(b, rs[i])
+			}
+			i++
+			ts = append(ts, sqlToken{"string", string(b), start, i})
+		case c == '"':
+			b := []rune{}
+			for i++; ; i++ {
+				if i >= len(rs) {
+					return nil, fmt.Errorf("unterminated identifier at offset %d", start)
+				}
+				if rs[i] == '"' {
+					if i+1 < len(rs) && rs[i+1] == '"' {
+						b = append(b, '"')
+						i++
+						continue
+					}
+					break
+				}
+				b = append(b, rs[i])
+			}
+			i++
+			// .erdm の名前に使えない文字は _ にする
+			ts = append(ts, sqlToken{"qident", erdmIdentifier(string(b)), start, i})
+		case c == '$':
+			// 関数本体などの $tag$ ... $tag$ は 1 つの文字列として読み飛ばす
+			j := i + 1
+			for j < len(rs) && (rs[j] == '_' || unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j])) {
+				j++
+			}
+			if j >= len(rs) || rs[j] != '$' {
+				ts = append(ts, sqlToken{"symbol", "$", start, i + 1})
+				i++
+				continue
+			}
+			tag := string(rs[i : j+1])
+			end := strings.Index(string(rs[j+1:]), tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated %s string at offset %d", tag, start)
+			}
+			body := string(rs[j+1:])[:end]
+			i = j + 1 + len([]rune(body)) + len([]rune(tag))
+			ts = append(ts, sqlToken{"string", body, start, i})
+		case c == '_' || unicode.IsLetter(c):
+			for i < len(rs) && (rs[i] == '_' || rs[i] == '$' || unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i])) {
+				i++
+			}
+			ts = append(ts, sqlToken{"ident", string(rs[start:i]), start, i})
+		case unicode.IsDigit(c):
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			ts = append(ts, sqlToken{"number", string(rs[start:i]), start, i})
+		case c == ':' && i+1 < len(rs) && rs[i+1] == ':':
+			i += 2
+			ts = append(ts, sqlToken{"symbol", "::", start, i})
+		default:
+			i++
+			ts = append(ts, sqlToken{"symbol", string(c), start, i})
+		}
+	}
+	if len(ts) > 0 {
+		stmts = append(stmts, ts)
+	}
+	return stmts, nil
+}
+
+type sqlStatement struct {
+	tokens []sqlToken
+	pos    int
+}
+
+func (s *sqlStatement) eof() bool {
+	return s.pos >= len(s.tokens)
+}
+
+func (s *sqlStatement) peek() sqlToken {
+	if s.eof() {
+		return sqlToken{}
+	}
+	return s.tokens[s.pos]
+}
+
+func (s *sqlStatement) next() sqlToken {
+	t := s.peek()
+	if !s.eof() {
+		s.pos++
+	}
+	return t
+}
+
+// accept は先頭が kws の並びと一致すれば読み進めて true を返す。
+func (s *sqlStatement) accept(kws ...string) bool {
+	for i, kw := range kws {
+		if s.pos+i >= len(s.tokens) {
+			return false
+		}
+		t := s.tokens[s.pos+i]
+		if !t.is(kw) && !(t.kind == "symbol" && t.text == kw) {
+			return false
+		}
+	}
+	s.pos += len(kws)
+	return true
+}
+
+// name は schema.table のような修飾名を読み、最後の要素を返す。
+func (s *sqlStatement) name() string {
+	n := s.next().text
+	for s.peek().kind == "symbol" && s.peek().text == "." {
+		s.next()
+		n = s.next().text
+	}
+	return n
+}
+
+// nameList は (a, b) を読む。式が含まれていれば ok は false になる。
+func (s *sqlStatement) nameList() (names []string, ok bool) {
+	if !s.accept("(") {
+		return nil, false
+	}
+	ok = true
+	for !s.eof() {
+		t := s.next()
+		if t.kind != "ident" && t.kind != "qident" {
+			ok = false
+		}
+		names = append(names, t.text)
+		// DESC や COLLATE などの修飾は読み捨て、記号があれば式とみなす
+		from, to := s.skipTo(",", ")")
+		for _, u := range s.tokens[from:to] {
+			if u.kind == "symbol" {
+				ok = false
+			}
+		}
+		if s.accept(")") {
+			return names, ok
+		}
+		s.accept(",")
+	}
+	return names, false
+}
+
+// skipTo は , や ) など、括弧の外にある stops のいずれかの直前まで読み進めて、読み飛ばした範囲を返す。
+func (s *sqlStatement) skipTo(stops ...string) (int, int) {
+	start := s.pos
+	for depth := 0; !s.eof(); s.pos++ {
+		t := s.tokens[s.pos]
+		if depth == 0 {
+			for _, stop := range stops {
+				if t.is(stop) || (t.kind == "symbol" && t.text == stop) {
+					return start, s.pos
+				}
+			}
+		}
+		if t.kind == "symbol" && t.text == "(" {
+			depth++
+		} else if t.kind == "symbol" && t.text == ")" {
+			depth--
+		}
+	}
+	return start, s.pos
+}
+
+type sqlForeignKey struct {
+	table      string
+	columns    []string
+	refTable   string
+	refColumns []string
+}
+
+type sqlImporter struct {
+	src         string
+	erd         *Schema
+	foreignKeys []sqlForeignKey
+	warnings    []string
+}
+
+func (im *sqlImporter) warn(format string, a ...interface{}) {
+	im.warnings = append(im.warnings, "warning: "+fmt.Sprintf(format, a...))
+}
+
+func (im *sqlImporter) text(s *sqlStatement, from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return string([]rune(im.src)[s.tokens[from].start:s.tokens[to-1].end])
+}
+
+// checkRenamed は "..." で囲まれた名前が .erdm 用に書き換えられていれば警告する。
+func (im *sqlImporter) checkRenamed(t sqlToken) {
+	if t.kind != "qident" {
+		return
+	}
+	if raw := string([]rune(im.src)[t.start+1 : t.end-1]); raw != t.text {
+		im.warn("name %q is written as %s", raw, t.text)
+	}
+}
+
+// selectTable は currentTableId を table に合わせる。
+func (im *sqlImporter) selectTable(table string) bool {
+	i := im.erd.getTableIndex(table)
+	if i < 0 {
+		im.warn("unknown table %s is ignored", table)
+		return false
+	}
+	im.erd.currentTableId = i
+	return true
+}
+
+// selectColumn は currentTableId / currentColumnId を table.column に合わせる。
+func (im *sqlImporter) selectColumn(table, column string) bool {
+	if !im.selectTable(table) {
+		return false
+	}
+	t := &im.erd.Tables[im.erd.currentTableId]
+	i, err := t.getColumnIndex(column)
+	if err != nil {
+		im.warn("unknown column %s.%s is ignored", table, column)
+		return false
+	}
+	im.erd.currentColumnId = i
+	return true
+}
+
+var sqlColumnConstraints = []string{"not", "null", "default", "primary", "unique", "references", "constraint", "check", "collate", "generated"}
+
+// sqlTypeSpellings は pg_dump が書く型名を .erdm でよく使う短い綴りにする。
+var sqlTypeSpellings = map[string]string{
+	"character varying":           "varchar",
+	"character":                   "char",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+}
+
+var sqlTypePattern = regexp.MustCompile(`^([a-z ]+?)\s*(\([0-9, ]+\))?(\s+with(?:out)? time zone)?$`)
+
+func normalizeSQLType(t string) string {
+	t = normalizeType(strings.Replace(t, "\"", "", -1))
+	t = strings.TrimPrefix(t, "pg_catalog.")
+	t = strings.TrimPrefix(t, "public.")
+	array := strings.HasSuffix(t, "[]")
+	t = strings.TrimSuffix(t, "[]")
+	if m := sqlTypePattern.FindStringSubmatch(t); m != nil {
+		if n, ok := sqlTypeSpellings[m[1]+m[3]]; ok {
+			t = n + strings.Replace(m[2], " ", "", -1)
+		}
+	}
+	if array {
+		// col_type に [] は書けないので、PostgreSQL でも通る "integer array" の形にする
+		t += " array"
+	}
+	return t
+}
+
+var sqlCastPattern = regexp.MustCompile(`^('(?:[^']|'')*')::[a-z_ "]+(\([0-9, ]+\))?(\[\])?$`)
+
+// normalizeSQLDefault は 'abc'::character varying のような pg_dump のキャストを取り除く。
+func normalizeSQLDefault(d string) string {
+	if m := sqlCastPattern.FindStringSubmatch(d); m != nil {
+		return m[1]
+	}
+	return d
+}
+
+// ReadSQL は PostgreSQL の DDL を読み込んで Schema を作る。対応しない文は読み飛ばし、warnings に理由を返す。
+func ReadSQL(src string) (*Schema, []string, error) {
+	stmts, err := tokenizeSQL(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	im := &sqlImporter{src: src, erd: &Schema{}}
+	for _, ts := range stmts {
+		s := &sqlStatement{tokens: ts}
+		switch {
+		case s.accept("create"):
+			for s.accept("global") || s.accept("local") || s.accept("temporary") || s.accept("temp") || s.accept("unlogged") {
+			}
+			switch {
+			case s.accept("table"):
+				im.createTable(s)
+			case s.accept("unique", "index"):
+				im.createIndex(s, true)
+			case s.accept("index"):
+				im.createIndex(s, false)
+			}
+		case s.accept("alter", "table"):
+			im.alterTable(s)
+		case s.accept("comment", "on"):
+			im.comment(s)
+		}
+	}
+	im.applyForeignKeys()
+	return im.erd, im.warnings, nil
+}
+
+func (im *sqlImporter) createTable(s *sqlStatement) {
+	s.accept("if", "not", "exists")
+	name := s.name()
+	if im.erd.getTableIndex(name) >= 0 {
+		im.warn("table %s is defined twice; the second definition is ignored", name)
+		return
+	}
+	im.checkRenamed(s.tokens[s.pos-1])
+	if !s.accept("(") {
+		im.warn("table %s is not a plain CREATE TABLE (...) and is ignored", name)
+		return
+	}
+	im.erd.addTableTitleReal(name)
+	for !s.eof() && !s.accept(")") {
+		switch {
+		case s.accept("constraint"):
+			im.tableConstraint(s, name, s.next().text)
+		case s.peek().is("primary") || s.peek().is("unique") || s.peek().is("foreign") || s.peek().is("check") || s.peek().is("exclude"):
+			im.tableConstraint(s, name, "")
+		case s.accept("like"):
+			s.skipTo(",", ")")
+			im.warn("LIKE in table %s is ignored", name)
+		default:
+			im.column(s, name)
+		}
+		s.skipTo(",", ")")
+		s.accept(",")
+	}
+}
+
+func (im *sqlImporter) column(s *sqlStatement, table string) {
+	e := im.erd
+	name := s.next().text
+	im.checkRenamed(s.tokens[s.pos-1])
+	e.setColumnNameReal(name)
+	from, to := s.skipTo(append([]string{",", ")"}, sqlColumnConstraints...)...)
+	t := normalizeSQLType(im.text(s, from, to))
+	serial := false
+	for !s.eof() && !(s.peek().kind == "symbol" && (s.peek().text == "," || s.peek().text == ")")) {
+		switch {
+		case s.accept("constraint"):
+			s.next()
+		case s.accept("not", "null"):
+			e.setNotNull()
+		case s.accept("null"):
+		case s.accept("primary", "key"):
+			tb := &e.Tables[e.currentTableId]
+			tb.addPrimaryKeyColumn(e.currentColumnId)
+			e.setNotNull()
+		case s.accept("unique"):
+			e.setUnique()
+		case s.accept("default"):
+			from, to := s.skipTo(append([]string{",", ")"}, sqlColumnConstraints...)...)
+			d := normalizeSQLDefault(im.text(s, from, to))
+			if strings.HasPrefix(strings.ToLower(d), "nextval(") {
+				serial = true
+			} else {
+				im.setDefault(table, name, d)
+			}
+		case s.accept("generated"):
+			from, to := s.skipTo(append([]string{",", ")"}, sqlColumnConstraints...)...)
+			if strings.Contains(strings.ToLower(im.text(s, from, to)), "identity") {
+				serial = true
+				e.setNotNull()
+			} else {
+				im.warn("generated column %s.%s is written as a plain column", table, name)
+			}
+		case s.accept("references"):
+			ref := s.name()
+			cs, _ := s.nameList()
+			im.foreignKeys = append(im.foreignKeys, sqlForeignKey{table, []string{name}, ref, cs})
+			// ON DELETE SET NULL などの NULL / DEFAULT を制約と取り違えないよう先に読む
+			for s.accept("on") {
+				s.next()
+				if !s.accept("set", "null") && !s.accept("set", "default") && !s.accept("no", "action") {
+					s.next()
+				}
+			}
+		default:
+			s.next()
+			s.skipTo(append([]string{",", ")"}, sqlColumnConstraints...)...)
+		}
+	}
+	if serial {
+		t = serialType(t)
+	}
+	e.addColumnType(t)
+}
+
+// serialType は nextval や IDENTITY で採番される整数型を serial / bigserial にする。
+func serialType(t string) string {
+	ct := parseCanonicalType(t)
+	if ct.Array {
+		return t
+	}
+	switch ct.Kind {
+	case "bigint":
+		return "bigserial"
+	case "integer", "smallint":
+		return "serial"
+	}
+	return t
+}
+
+func (im *sqlImporter) setDefault(table, column, d string) {
+	if strings.ContainsAny(d, "]\r\n") {
+		im.warn("default value of %s.%s cannot be written in .erdm and is ignored: %s", table, column, d)
+		return
+	}
+	im.erd.setColumnDefault(d)
+}
+
+// tableConstraint は PRIMARY KEY (...) / UNIQUE (...) / FOREIGN KEY (...) REFERENCES ... を読む。
+func (im *sqlImporter) tableConstraint(s *sqlStatement, table, constraintName string) {
+	switch {
+	case s.accept("primary", "key"):
+		cs, ok := s.nameList()
+		if !ok || !im.selectTable(table) {
+			return
+		}
+		t := &im.erd.Tables[im.erd.currentTableId]
+		for _, c := range cs {
+			if i, err := t.getColumnIndex(c); err == nil {
+				t.addPrimaryKeyColumn(i)
+				t.Columns[i].AllowNull = false
+			} else {
+				im.warn("unknown primary key column %s.%s is ignored", table, c)
+			}
+		}
+	case s.accept("unique"):
+		cs, ok := s.nameList()
+		if !ok {
+			im.warn("UNIQUE constraint %s of %s is not a column list and is ignored", constraintName, table)
+			return
+		}
+		if len(cs) == 1 {
+			if im.selectColumn(table, cs[0]) {
+				im.erd.setUnique()
+			}
+			return
+		}
+		im.addIndex(table, constraintName, cs, true)
+	case s.accept("foreign", "key"):
+		cs, _ := s.nameList()
+		if !s.accept("references") {
+			return
+		}
+		ref := s.name()
+		rcs, _ := s.nameList()
+		im.foreignKeys = append(im.foreignKeys, sqlForeignKey{table, cs, ref, rcs})
+	case s.accept("check"), s.accept("exclude"):
+		im.warn("CHECK/EXCLUDE constraint %s of %s is ignored", constraintName, table)
+	}
+}
+
+func (im *sqlImporter) addIndex(table, name string, columns []string, unique bool) {
+	if !im.selectTable(table) {
+		return
+	}
+	t := &im.erd.Tables[im.erd.currentTableId]
+	if len(name) == 0 {
+		name = "i_" + table + "_" + strings.Join(columns, "_")
+	}
+	for _, c := range columns {
+		if _, err := t.getColumnIndex(c); err != nil {
+			im.warn("index %s refers to unknown column %s.%s and is ignored", name, table, c)
+			return
+		}
+	}
+	im.erd.setIndexName(erdmIdentifier(name))
+	for _, c := range columns {
+		im.erd.setIndexColumn(c)
+	}
+	if unique {
+		im.erd.setUniqueIndex()
+	}
+}
+
+func (im *sqlImporter) createIndex(s *sqlStatement, unique bool) {
+	s.accept("concurrently")
+	s.accept("if", "not", "exists")
+	name := ""
+	if !s.peek().is("on") {
+		name = s.name()
+	}
+	if !s.accept("on") {
+		return
+	}
+	s.accept("only")
+	table := s.name()
+	if s.accept("using") {
+		s.next()
+	}
+	cs, ok := s.nameList()
+	if !ok {
+		im.warn("expression index %s on %s is ignored", name, table)
+		return
+	}
+	if s.accept("where") || s.accept("include") {
+		im.warn("partial or covering part of index %s on %s is ignored", name, table)
+	}
+	im.addIndex(table, name, cs, unique)
+}
+
+func (im *sqlImporter) alterTable(s *sqlStatement) {
+	s.accept("if", "exists")
+	s.accept("only")
+	table := s.name()
+	switch {
+	case s.accept("add", "constraint"):
+		im.tableConstraint(s, table, s.next().text)
+	case s.accept("add", "column"):
+		s.accept("if", "not", "exists")
+		if im.selectTable(table) {
+			im.column(s, table)
+		}
+	case s.accept("add"):
+		im.tableConstraint(s, table, "")
+	case s.accept("alter", "column") || s.accept("alter"):
+		column := s.next().text
+		switch {
+		case s.accept("set", "default"):
+			from, to := s.skipTo()
+			d := normalizeSQLDefault(im.text(s, from, to))
+			if !im.selectColumn(table, column) {
+				return
+			}
+			c := &im.erd.Tables[im.erd.currentTableId].Columns[im.erd.currentColumnId]
+			if strings.HasPrefix(strings.ToLower(d), "nextval(") {
+				im.erd.addColumnType(serialType(c.Type))
+			} else {
+				im.setDefault(table, column, d)
+			}
+		case s.accept("add", "generated"):
+			if im.selectColumn(table, column) {
+				c := &im.erd.Tables[im.erd.currentTableId].Columns[im.erd.currentColumnId]
+				im.erd.addColumnType(serialType(c.Type))
+			}
+		case s.accept("set", "not", "null"):
+			if im.selectColumn(table, column) {
+				im.erd.setNotNull()
+			}
+		}
+	}
+}
+
+// comment は COMMENT ON TABLE / COLUMN を読む。1 行目を論理名、2 行目以降をコメントにする。
+func (im *sqlImporter) comment(s *sqlStatement) {
+	switch {
+	case s.accept("table"):
+		table := s.name()
+		if !s.accept("is") || s.peek().kind != "string" || !im.selectTable(table) {
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(s.next().text), "\n")
+		im.erd.addTableTitle(strings.TrimSpace(lines[0]))
+		for _, l := range lines[1:] {
+			im.erd.addTableComment(strings.TrimRight(l, " \r"))
+		}
+	case s.accept("column"):
+		parts := []string{s.next().text}
+		for s.accept(".") {
+			parts = append(parts, s.next().text)
+		}
+		if len(parts) < 2 || !s.accept("is") || s.peek().kind != "string" {
+			return
+		}
+		table, column := parts[len(parts)-2], parts[len(parts)-1]
+		if !im.selectColumn(table, column) {
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(s.next().text), "\n")
+		im.erd.setColumnName(strings.TrimSpace(lines[0]))
+		for _, l := range lines[1:] {
+			im.erd.addComment(strings.TrimRight(l, " \r"))
+		}
+	}
+}
+
+// applyForeignKeys は FOREIGN KEY をカラムのリレーションにする。複数カラムの FOREIGN KEY は fk (...) にする。
+// 多重度は参照元カラムの NOT NULL と UNIQUE から決める。
+func (im *sqlImporter) applyForeignKeys() {
+	e := im.erd
+	for _, fk := range im.foreignKeys {
+		if len(fk.columns) != 1 {
+			im.applyCompositeForeignKey(fk)
+			continue
+		}
+		ri := e.getTableIndex(fk.refTable)
+		if ri < 0 {
+			im.warn("foreign key %s.%s refers to unknown table %s and is ignored", fk.table, fk.columns[0], fk.refTable)
+			continue
+		}
+		if !im.selectColumn(fk.table, fk.columns[0]) {
+			continue
+		}
+		t := &e.Tables[e.currentTableId]
+		c := &t.Columns[e.currentColumnId]
+		if c.IsUnique || (c.IsPrimaryKey && len(t.PrimaryKeys) == 1) {
+			e.setRelationSource("0..1")
+		} else {
+			e.setRelationSource("0..*")
+		}
+		if c.AllowNull {
+			e.setRelationDestination("0..1")
+		} else {
+			e.setRelationDestination("1")
+		}
+		target := fk.refTable
+		pks := e.Tables[ri].GetPrimaryKeyColumnNames()
+		if len(fk.refColumns) == 1 && (len(pks) != 1 || pks[0] != fk.refColumns[0]) {
+			target += "." + fk.refColumns[0]
+		}
+		e.setRelationTableNameReal(target)
+	}
+}
+
+func (im *sqlImporter) applyCompositeForeignKey(fk sqlForeignKey) {
+	e := im.erd
+	ri := e.getTableIndex(fk.refTable)
+	if ri < 0 {
+		im.warn("foreign key %s(%s) refers to unknown table %s and is ignored", fk.table, strings.Join(fk.columns, ", "), fk.refTable)
+		return
+	}
+	if !im.selectTable(fk.table) {
+		return
+	}
+	t := &e.Tables[e.currentTableId]
+	nullable := false
+	for _, c := range fk.columns {
+		i, err := t.getColumnIndex(c)
+		if err != nil {
+			im.warn("foreign key %s(%s) has unknown column %s and is ignored", fk.table, strings.Join(fk.columns, ", "), c)
+			return
+		}
+		nullable = nullable || t.Columns[i].AllowNull
+	}
+	e.addCompositeRelation()
+	for _, c := range fk.columns {
+		e.addCompositeRelationColumn(c)
+	}
+	if t.isUniqueColumns(fk.columns) {
+		e.setCompositeRelationSource("0..1")
+	} else {
+		e.setCompositeRelationSource("0..*")
+	}
+	if nullable {
+		e.setCompositeRelationDestination("0..1")
+	} else {
+		e.setCompositeRelationDestination("1")
+	}
+	e.setCompositeRelationTableNameReal(fk.refTable)
+	if !sameStrings(fk.refColumns, e.Tables[ri].GetPrimaryKeyColumnNames()) {
+		for _, c := range fk.refColumns {
+			e.addCompositeRelationReferenceColumn(c)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSQLSource = `-- users
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    email character varying(255) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+CREATE SEQUENCE users_id_seq;
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('users_id_seq'::regclass);
+CREATE TABLE articles (
+    id serial PRIMARY KEY,
+    user_id bigint REFERENCES users (id),
+    title text DEFAULT 'untitled'::text,
+    tenant_id bigint
+);
+CREATE TABLE tenants_users (tenant_id bigint NOT NULL, user_id bigint NOT NULL, PRIMARY KEY (tenant_id, user_id));
+ALTER TABLE articles ADD CONSTRAINT articles_tu_fk FOREIGN KEY (tenant_id, user_id) REFERENCES tenants_users (tenant_id, user_id);
+CREATE INDEX i_articles_user_id ON public.articles USING btree (user_id);
+CREATE UNIQUE INDEX i_articles_title ON articles (lower(title));
+COMMENT ON TABLE users IS 'site user';
+COMMENT ON COLUMN users.email IS 'login e-mail';
+CREATE VIEW v AS SELECT 1;
+`
+
+func TestReadSQL(t *testing.T) {
+	s, warnings, err := ReadSQL(testSQLSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Title: 
+
+users/"site user"
+    +id                  [bigserial][NN]
+    email/"login e-mail" [varchar(255)][NN][U]
+    created_at           [timestamptz][NN][=now()]
+
+articles
+    +id       [serial][NN]
+    user_id   [bigint] 0..*--0..1 users
+    title     [text][='untitled']
+    tenant_id [bigint]
+    fk (tenant_id, user_id) 0..*--0..1 tenants_users
+    index i_articles_user_id (user_id)
+
+tenants_users
+    +tenant_id [bigint][NN]
+    +user_id   [bigint][NN]
+`
+	if got := mustRender(t, "erdm", s); got != want {
+		t.Errorf("ReadSQL =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Join(warnings, "\n") != "warning: expression index i_articles_title on articles is ignored" {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
+
+func TestReadSQLArrayTypes(t *testing.T) {
+	s, _, err := ReadSQL("CREATE TABLE t (id int PRIMARY KEY, tags text[], scores numeric(5,2)[] NOT NULL);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 取り込んだ配列型を読み直しても pg の DDL に書ける
+	s.Title = "t"
+	r := mustParse(t, mustRender(t, "erdm", s))
+	if diags := r.CheckTypes([]string{"pg"}, true); len(diags) > 0 {
+		t.Errorf("CheckTypes(pg) = %v", diags)
+	}
+	if diags := r.CheckTypes([]string{"mysql"}, true); len(diags) != 2 || diags[0].Severity != SeverityWarning {
+		t.Errorf("CheckTypes(mysql) = %v, want a warning for each array column", diags)
+	}
+	assertInOrder(t, mustRender(t, "pg", r), []string{"    tags text[],\n", "    scores numeric(5,2)[] NOT NULL,\n"})
+}
+
+func TestReadSQLWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"duplicate table", "CREATE TABLE t (id int);\nCREATE TABLE t (id int);", "table t is defined twice; the second definition is ignored"},
+		{"create table as", "CREATE TABLE t AS SELECT 1;", "table t is not a plain CREATE TABLE (...) and is ignored"},
+		{"check", "CREATE TABLE t (id int, CONSTRAINT c CHECK (id > 0));", "CHECK/EXCLUDE constraint c of t is ignored"},
+		{"unknown reference", "CREATE TABLE t (id int REFERENCES u (id));", "foreign key t.id refers to unknown table u and is ignored"},
+		{"unknown table", "COMMENT ON TABLE u IS 'x';", "unknown table u is ignored"},
+		{"partial index", "CREATE TABLE t (id int);\nCREATE INDEX i ON t (id) WHERE id > 0;", "partial or covering part of index i on t is ignored"},
+		{"renamed", "CREATE TABLE \"User Table\" (id int);", `name "User Table" is written as`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, warnings, err := ReadSQL(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) == 0 || !strings.HasPrefix(warnings[0], "warning: "+tt.want) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSQLErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"CREATE TABLE t (name text DEFAULT 'x);", "unterminated string at offset 34"},
+		{`CREATE TABLE "t (id int);`, "unterminated identifier at offset 13"},
+		{"COMMENT ON TABLE t IS $$x;", "unterminated $$ string at offset 22"},
+	}
+	for _, tt := range tests {
+		if _, _, err := ReadSQL(tt.src); err == nil || err.Error() != tt.want {
+			t.Errorf("ReadSQL(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
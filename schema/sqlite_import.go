@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// import-sqlite: SQLite のデータベースファイルから .erdm を作る。
+// データベースは sqlite3 コマンドの -json 出力で読む。
+
+type sqliteTable struct {
+	Name string `json:"name"`
+}
+
+type sqliteColumn struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	NotNull int     `json:"notnull"`
+	Default *string `json:"dflt_value"`
+	PK      int     `json:"pk"`
+}
+
+type sqliteForeignKey struct {
+	Id    int     `json:"id"`
+	Seq   int     `json:"seq"`
+	Table string  `json:"table"`
+	From  string  `json:"from"`
+	To    *string `json:"to"`
+}
+
+type sqliteIndex struct {
+	Name    string `json:"name"`
+	Unique  int    `json:"unique"`
+	Origin  string `json:"origin"`
+	Partial int    `json:"partial"`
+}
+
+type sqliteIndexColumn struct {
+	Name *string `json:"name"`
+}
+
+// CheckSQLite は ReadSQLite に必要な sqlite3 コマンドがあるかを確かめる。
+func CheckSQLite() error {
+	return exec.Command("sqlite3", "-version").Run()
+}
+
+// querySQLite は sqlite3 -json で query を実行し、結果を v に読み込む。
+func querySQLite(db, query string, v interface{}) error {
+	out, err := exec.Command("sqlite3", "-readonly", "-json", db, query).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("sqlite3: %s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return err
+	}
+	// 結果が 0 行のときは何も出力されない
+	if len(strings.TrimSpace(string(out))) == 0 {
+		out = []byte("[]")
+	}
+	return json.Unmarshal(out, v)
+}
+
+// ReadSQLite は SQLite のデータベースファイルを読み込んで Schema を作る。
+func ReadSQLite(db string) (*Schema, []string, error) {
+	im := &sqlImporter{erd: &Schema{}}
+	e := im.erd
+	tables := []sqliteTable{}
+	err := querySQLite(db, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\' ORDER BY rowid", &tables)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, t := range tables {
+		columns := []sqliteColumn{}
+		if err = querySQLite(db, "SELECT * FROM pragma_table_info("+quoteString(t.Name)+") ORDER BY cid", &columns); err != nil {
+			return nil, nil, err
+		}
+		name := erdmIdentifier(t.Name)
+		if name != t.Name {
+			im.warn("name %q is written as %s", t.Name, name)
+		}
+		e.addTableTitleReal(name)
+		pks := []sqliteColumn{}
+		for _, c := range columns {
+			if c.PK > 0 {
+				pks = append(pks, c)
+			}
+		}
+		for _, c := range columns {
+			cn := erdmIdentifier(c.Name)
+			if cn != c.Name {
+				im.warn("name %q is written as %s", c.Name, cn)
+			}
+			e.setColumnNameReal(cn)
+			typ := normalizeType(c.Type)
+			if len(typ) == 0 {
+				// 型のないカラムは BLOB 親和性になる
+				im.warn("column %s.%s has no type and is written as blob", name, cn)
+				typ = "blob"
+			}
+			if len(pks) == 1 && c.PK > 0 && typ == "integer" {
+				// INTEGER PRIMARY KEY は rowid の別名で、自動採番される
+				typ = "serial"
+			}
+			e.addColumnType(typ)
+			if c.NotNull != 0 {
+				e.setNotNull()
+			}
+			if c.Default != nil {
+				im.setDefault(name, cn, *c.Default)
+			}
+		}
+		sort.Slice(pks, func(i, j int) bool { return pks[i].PK < pks[j].PK })
+		for _, c := range pks {
+			i, _ := e.Tables[e.currentTableId].getColumnIndex(erdmIdentifier(c.Name))
+			e.Tables[e.currentTableId].addPrimaryKeyColumn(i)
+		}
+
+		indexes := []sqliteIndex{}
+		if err = querySQLite(db, "SELECT * FROM pragma_index_list("+quoteString(t.Name)+") ORDER BY seq DESC", &indexes); err != nil {
+			return nil, nil, err
+		}
+		for _, idx := range indexes {
+			if idx.Origin == "pk" {
+				continue
+			}
+			ics := []sqliteIndexColumn{}
+			if err = querySQLite(db, "SELECT name FROM pragma_index_info("+quoteString(idx.Name)+") ORDER BY seqno", &ics); err != nil {
+				return nil, nil, err
+			}
+			cs := []string{}
+			for _, ic := range ics {
+				if ic.Name == nil {
+					cs = nil
+					break
+				}
+				cs = append(cs, erdmIdentifier(*ic.Name))
+			}
+			if cs == nil {
+				im.warn("expression index %s on %s is ignored", idx.Name, name)
+				continue
+			}
+			if idx.Partial != 0 {
+				im.warn("partial part of index %s on %s is ignored", idx.Name, name)
+			}
+			// UNIQUE 制約の自動インデックスは名前を付けられないので、1 カラムなら [U] にする
+			if idx.Origin == "u" {
+				if len(cs) == 1 {
+					if im.selectColumn(name, cs[0]) {
+						e.setUnique()
+					}
+					continue
+				}
+				im.addIndex(name, "", cs, true)
+				continue
+			}
+			im.addIndex(name, idx.Name, cs, idx.Unique != 0)
+		}
+
+		fks := []sqliteForeignKey{}
+		if err = querySQLite(db, "SELECT * FROM pragma_foreign_key_list("+quoteString(t.Name)+") ORDER BY id DESC, seq", &fks); err != nil {
+			return nil, nil, err
+		}
+		for _, fk := range fks {
+			if fk.Seq > 0 {
+				last := &im.foreignKeys[len(im.foreignKeys)-1]
+				last.columns = append(last.columns, erdmIdentifier(fk.From))
+				if fk.To != nil {
+					last.refColumns = append(last.refColumns, erdmIdentifier(*fk.To))
+				}
+				continue
+			}
+			f := sqlForeignKey{table: name, columns: []string{erdmIdentifier(fk.From)}, refTable: erdmIdentifier(fk.Table)}
+			if fk.To != nil {
+				f.refColumns = []string{erdmIdentifier(*fk.To)}
+			}
+			im.foreignKeys = append(im.foreignKeys, f)
+		}
+	}
+	im.applyForeignKeys()
+	return e, im.warnings, nil
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSQLite(t *testing.T) {
+	if err := CheckSQLite(); err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+	db := filepath.Join(t.TempDir(), "shop.db")
+	ddl := `CREATE TABLE users (id INTEGER PRIMARY KEY, email VARCHAR(255) NOT NULL UNIQUE, created TEXT DEFAULT CURRENT_TIMESTAMP, memo);
+CREATE TABLE articles (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id), title TEXT DEFAULT 'x');
+CREATE INDEX i_articles_user_id ON articles (user_id);
+CREATE INDEX i_articles_title ON articles (lower(title));`
+	if out, err := exec.Command("sqlite3", db, ddl).CombinedOutput(); err != nil {
+		t.Fatalf("sqlite3: %v %s", err, out)
+	}
+	s, warnings, err := ReadSQLite(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Title = "shop"
+	want := `# Title: shop
+
+users
+    +id     [serial]
+    email   [varchar(255)][NN][U]
+    created [text][=CURRENT_TIMESTAMP]
+    memo    [blob]
+
+articles
+    +id     [serial]
+    user_id [integer][NN] 0..*--1 users
+    title   [text][='x']
+    index i_articles_user_id (user_id)
+`
+	if got := mustRender(t, "erdm", s); got != want {
+		t.Errorf("ReadSQLite =\n%s\nwant\n%s", got, want)
+	}
+	if got := strings.Join(warnings, "\n"); got != "warning: column users.memo has no type and is written as blob\nwarning: expression index i_articles_title on articles is ignored" {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
+
+func TestReadSQLiteNoDatabase(t *testing.T) {
+	if err := CheckSQLite(); err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+	if _, _, err := ReadSQLite(filepath.Join(t.TempDir(), "none", "none.db")); err == nil {
+		t.Error("ReadSQLite succeeded without a database")
+	}
+}
@@ -1,10 +1,10 @@
-package main
+package schema
 
 import "embed"
 
 //go:embed templates/*.tmpl
 var templatesFS embed.FS
 
-func Asset(name string) ([]byte, error) {
+func asset(name string) ([]byte, error) {
 	return templatesFS.ReadFile(name)
 }
@@ -0,0 +1,425 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CanonicalType は [col_type] に書かれた型を方言に依存しない形で表したもの。
+// Kind が空なら認識できなかった型で、Raw をそのまま使うしかない。
+type CanonicalType struct {
+	Kind         string
+	Length       int
+	Precision    int
+	Scale        int
+	WithTimeZone bool
+	Binary       bool
+	Array        bool
+	Raw          string
+}
+
+var dialects = []string{"pg", "sqlite3", "mysql", "mssql", "oracle"}
+
+// typeSpellings はよく使われる型名の綴りを正規化済みの名前から canonical な Kind に対応させる。
+var typeSpellings = map[string]string{
+	"smallint":                    "smallint",
+	"int2":                        "smallint",
+	"integer":                     "integer",
+	"int":                         "integer",
+	"int4":                        "integer",
+	"bigint":                      "bigint",
+	"int8":                        "bigint",
+	"tinyint":                     "smallint",
+	"mediumint":                   "integer",
+	"smallserial":                 "serial",
+	"serial2":                     "serial",
+	"serial":                      "serial",
+	"serial4":                     "serial",
+	"bigserial":                   "bigserial",
+	"serial8":                     "bigserial",
+	"varchar":                     "string",
+	"character varying":           "string",
+	"nvarchar":                    "string",
+	"varchar2":                    "string",
+	"nvarchar2":                   "string",
+	"string":                      "string",
+	"char":                        "char",
+	"character":                   "char",
+	"nchar":                       "char",
+	"text":                        "text",
+	"clob":                        "text",
+	"ntext":                       "text",
+	"mediumtext":                  "text",
+	"longtext":                    "text",
+	"decimal":                     "decimal",
+	"numeric":                     "decimal",
+	"number":                      "decimal",
+	"real":                        "float",
+	"float4":                      "float",
+	"float":                       "double",
+	"float8":                      "double",
+	"double":                      "double",
+	"double precision":            "double",
+	"date":                        "date",
+	"time":                        "time",
+	"time without time zone":      "time",
+	"timestamp":                   "timestamp",
+	"timestamp without time zone": "timestamp",
+	"datetime":                    "timestamp",
+	"datetime2":                   "timestamp",
+	"timestamptz":                 "timestamptz",
+	"timestamp with time zone":    "timestamptz",
+	"datetimeoffset":              "timestamptz",
+	"boolean":                     "bool",
+	"bool":                        "bool",
+	"bit":                         "bool",
+	"uuid":                        "uuid",
+	"uniqueidentifier":            "uuid",
+	"json":                        "json",
+	"jsonb":                       "jsonb",
+	"bytea":                       "bytes",
+	"blob":                        "bytes",
+	"longblob":                    "bytes",
+	"varbinary":                   "bytes",
+	"binary":                      "bytes",
+	"inet":                        "inet",
+	"money":                       "money",
+	"enum":                        "enum",
+}
+
+// 各方言の型対応表。キーは CanonicalType.key() で、値の %s は "(128)" のような型引数に置き換える。
+var dialectTypes = map[string]map[string]string{
+	"pg": {
+		"smallint":    "smallint",
+		"integer":     "integer",
+		"bigint":      "bigint",
+		"serial":      "serial",
+		"bigserial":   "bigserial",
+		"string":      "varchar%s",
+		"char":        "char%s",
+		"text":        "text",
+		"decimal":     "numeric%s",
+		"float":       "real",
+		"double":      "double precision",
+		"date":        "date",
+		"time":        "time%s",
+		"timestamp":   "timestamp%s",
+		"timestamptz": "timestamp%s with time zone",
+		"bool":        "boolean",
+		"uuid":        "uuid",
+		"json":        "json",
+		"jsonb":       "jsonb",
+		"bytes":       "bytea",
+		"inet":        "inet",
+		"money":       "money",
+		"enum":        "text",
+	},
+	"sqlite3": {
+		"smallint":    "integer",
+		"integer":     "integer",
+		"bigint":      "integer",
+		"serial":      "integer",
+		"bigserial":   "integer",
+		"string":      "varchar%s",
+		"char":        "char%s",
+		"text":        "text",
+		"decimal":     "numeric%s",
+		"float":       "real",
+		"double":      "real",
+		"date":        "date",
+		"time":        "time",
+		"timestamp":   "timestamp",
+		"timestamptz": "timestamp",
+		"bool":        "boolean",
+		"uuid":        "text",
+		"json":        "text",
+		"jsonb":       "text",
+		"bytes":       "blob",
+		"inet":        "text",
+		"money":       "numeric",
+		"enum":        "text",
+	},
+	"mysql": {
+		"smallint":    "smallint",
+		"integer":     "int",
+		"bigint":      "bigint",
+		"serial":      "int",
+		"bigserial":   "bigint",
+		"string":      "varchar%s",
+		"char":        "char%s",
+		"text":        "text",
+		"decimal":     "decimal%s",
+		"float":       "float",
+		"double":      "double",
+		"date":        "date",
+		"time":        "time%s",
+		"timestamp":   "datetime%s",
+		"timestamptz": "datetime%s",
+		"bool":        "tinyint(1)",
+		"uuid":        "char(36)",
+		"json":        "json",
+		"jsonb":       "json",
+		"bytes":       "longblob",
+		"inet":        "varchar(45)",
+		"money":       "decimal(19,4)",
+		"enum":        "varchar(255)",
+	},
+	"mssql": {
+		"smallint":    "smallint",
+		"integer":     "int",
+		"bigint":      "bigint",
+		"serial":      "int",
+		"bigserial":   "bigint",
+		"string":      "nvarchar%s",
+		"char":        "nchar%s",
+		"text":        "nvarchar(max)",
+		"decimal":     "decimal%s",
+		"float":       "real",
+		"double":      "float",
+		"date":        "date",
+		"time":        "time%s",
+		"timestamp":   "datetime2%s",
+		"timestamptz": "datetimeoffset%s",
+		"bool":        "bit",
+		"uuid":        "uniqueidentifier",
+		"json":        "nvarchar(max)",
+		"jsonb":       "nvarchar(max)",
+		"bytes":       "varbinary(max)",
+		"inet":        "nvarchar(45)",
+		"money":       "money",
+		"enum":        "nvarchar(255)",
+	},
+	"oracle": {
+		"smallint":    "number(5)",
+		"integer":     "number(10)",
+		"bigint":      "number(19)",
+		"serial":      "number(10)",
+		"bigserial":   "number(19)",
+		"string":      "varchar2%s",
+		"char":        "char%s",
+		"text":        "clob",
+		"decimal":     "number%s",
+		"float":       "binary_float",
+		"double":      "binary_double",
+		"date":        "date",
+		"timestamp":   "timestamp%s",
+		"timestamptz": "timestamp%s with time zone",
+		"bool":        "number(1)",
+		"uuid":        "varchar2(36)",
+		"json":        "clob",
+		"jsonb":       "clob",
+		"bytes":       "blob",
+		"inet":        "varchar2(45)",
+		"money":       "number(19,4)",
+		"enum":        "varchar2(255)",
+	},
+}
+
+// 方言ごとのデフォルト値の読み替え。PostgreSQL の now() などはそのままでは通らない。
+var dialectDefaults = map[string]map[string]string{
+	"sqlite3": {
+		"now()": "CURRENT_TIMESTAMP",
+		"true":  "1",
+		"false": "0",
+	},
+	"mssql": {
+		"now()": "CURRENT_TIMESTAMP",
+		"true":  "1",
+		"false": "0",
+	},
+	"oracle": {
+		"now()": "CURRENT_TIMESTAMP",
+		"true":  "1",
+		"false": "0",
+	},
+}
+
+var typeArgsPattern = regexp.MustCompile(`^([a-z_ 0-9]*?)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)\s*(.*)$`)
+
+func normalizeType(t string) string {
+	return strings.Join(strings.Fields(strings.ToLower(t)), " ")
+}
+
+// parseCanonicalType は型の綴りを解釈する。"varchar(128)" や "timestamp(3) with time zone" のような
+// 型引数付きの書き方も受け付ける。
+func parseCanonicalType(raw string) CanonicalType {
+	ct := CanonicalType{Raw: raw}
+	n := normalizeType(raw)
+	// PostgreSQL の配列は "text array" または "text[]" と書く
+	if e := strings.TrimSuffix(strings.TrimSuffix(n, "[]"), " array"); e != n {
+		elem := parseCanonicalType(e)
+		if !elem.IsKnown() || elem.IsSerial() {
+			return ct
+		}
+		elem.Array, elem.Raw = true, raw
+		return elem
+	}
+	a, b := 0, 0
+	hasArgs := false
+	if m := typeArgsPattern.FindStringSubmatch(n); m != nil {
+		n = strings.TrimSpace(m[1] + " " + m[4])
+		a, _ = strconv.Atoi(m[2])
+		if len(m[3]) > 0 {
+			b, _ = strconv.Atoi(m[3])
+		}
+		hasArgs = true
+	}
+	kind, ok := typeSpellings[n]
+	if !ok {
+		return ct
+	}
+	switch kind {
+	case "string", "char":
+		if !hasArgs {
+			if kind == "string" {
+				// 長さ指定のない varchar は PostgreSQL では text と同じ
+				kind = "text"
+			} else {
+				a = 1
+			}
+		}
+		ct.Length = a
+	case "decimal":
+		ct.Precision, ct.Scale = a, b
+	case "time", "timestamp", "timestamptz":
+		ct.Precision = a
+	case "jsonb":
+		kind, ct.Binary = "json", true
+	case "smallint", "integer", "bigint", "float", "double", "bytes":
+		// int(11) のような表示幅や varbinary(16) の長さは読み捨てる
+	default:
+		if hasArgs {
+			return ct
+		}
+	}
+	if kind == "timestamptz" {
+		kind, ct.WithTimeZone = "timestamp", true
+	}
+	ct.Kind = kind
+	return ct
+}
+
+func (ct CanonicalType) IsKnown() bool {
+	return len(ct.Kind) > 0
+}
+
+func (ct CanonicalType) IsSerial() bool {
+	return ct.Kind == "serial" || ct.Kind == "bigserial"
+}
+
+// baseType は serial / bigserial を元の整数型に読み替えた型を返す。
+func (ct CanonicalType) baseType() CanonicalType {
+	switch ct.Kind {
+	case "serial":
+		ct.Kind = "integer"
+	case "bigserial":
+		ct.Kind = "bigint"
+	}
+	return ct
+}
+
+func (ct CanonicalType) key() string {
+	k := ct.Kind
+	if ct.Kind == "timestamp" && ct.WithTimeZone {
+		k = "timestamptz"
+	}
+	if ct.Kind == "json" && ct.Binary {
+		k = "jsonb"
+	}
+	if ct.Array {
+		return k + "[]"
+	}
+	return k
+}
+
+func (ct CanonicalType) args() string {
+	switch ct.Kind {
+	case "string", "char":
+		return fmt.Sprintf("(%d)", ct.Length)
+	case "decimal":
+		if ct.Precision == 0 {
+			return ""
+		}
+		if ct.Scale == 0 {
+			return fmt.Sprintf("(%d)", ct.Precision)
+		}
+		return fmt.Sprintf("(%d,%d)", ct.Precision, ct.Scale)
+	case "time", "timestamp":
+		if ct.Precision > 0 {
+			return fmt.Sprintf("(%d)", ct.Precision)
+		}
+	}
+	return ""
+}
+
+// mapTo は方言の型名を返す。対応がなければ Raw をそのまま返し、ok は false になる。
+func (ct CanonicalType) mapTo(dialect string) (string, bool) {
+	if !ct.IsKnown() {
+		return ct.Raw, false
+	}
+	if ct.Array {
+		// 配列型は PostgreSQL にしかない
+		elem := ct
+		elem.Array = false
+		if t, ok := elem.mapTo(dialect); ok && dialect == "pg" {
+			return t + "[]", true
+		}
+		return ct.Raw, false
+	}
+	v, ok := dialectTypes[dialect][ct.key()]
+	if !ok {
+		return ct.Raw, false
+	}
+	if strings.Contains(v, "%s") {
+		return strings.Replace(v, "%s", ct.args(), 1), true
+	}
+	return v, true
+}
+
+// IsSerial は bigserial などの自動採番型かどうかを返す。
+func (c *Column) IsSerial() bool {
+	return c.Canonical.IsSerial()
+}
+
+// GetDialectType はテンプレートから方言ごとの型名を得るために使う。
+func (c *Column) GetDialectType(dialect string) string {
+	t, _ := c.Canonical.mapTo(dialect)
+	return t
+}
+
+func (c *Column) GetDialectDefault(dialect string) string {
+	if v, ok := dialectDefaults[dialect][strings.ToLower(strings.TrimSpace(c.Default))]; ok {
+		return v
+	}
+	return c.Default
+}
+
+// CheckTypes は ds の各方言で型が読み替えられるかを確かめる。
+// 認識できない型はそのまま書き出して警告にし、strict ならエラーにする。方言に対応のない型は警告にする。
+// ds が空（DDL を書かない）なら型は読み替えないので何も報告しない。
+func (e *Schema) CheckTypes(ds []string, strict bool) []Diagnostic {
+	diags := []Diagnostic{}
+	if len(ds) == 0 {
+		return diags
+	}
+	for _, t := range e.Tables {
+		for _, c := range t.Columns {
+			if !c.Canonical.IsKnown() {
+				if strict {
+					diags = append(diags, newDiagnostic(SeverityError, c.Position, "unknown type %q of %s.%s", c.Type, t.TitleReal, c.TitleReal))
+				} else {
+					diags = append(diags, newDiagnostic(SeverityWarning, c.Position, "unknown type %q of %s.%s is written verbatim", c.Type, t.TitleReal, c.TitleReal))
+				}
+				continue
+			}
+			for _, d := range ds {
+				if _, ok := c.Canonical.mapTo(d); !ok {
+					diags = append(diags, newDiagnostic(SeverityWarning, c.Position, "type %q of %s.%s has no mapping for %s; written verbatim", c.Type, t.TitleReal, c.TitleReal, d))
+				}
+			}
+		}
+	}
+	return diags
+}
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapTo(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want map[string]string
+	}{
+		{"bigserial", map[string]string{"pg": "bigserial", "sqlite3": "integer", "mysql": "bigint", "mssql": "bigint", "oracle": "number(19)"}},
+		{"varchar(128)", map[string]string{"pg": "varchar(128)", "mysql": "varchar(128)", "mssql": "nvarchar(128)", "oracle": "varchar2(128)"}},
+		{"varchar", map[string]string{"pg": "text", "mysql": "text", "oracle": "clob"}},
+		{"numeric(10, 2)", map[string]string{"pg": "numeric(10,2)", "mysql": "decimal(10,2)", "oracle": "number(10,2)"}},
+		{"timestamptz", map[string]string{"pg": "timestamp with time zone", "mysql": "datetime", "mssql": "datetimeoffset"}},
+		{"timestamp(3) with time zone", map[string]string{"pg": "timestamp(3) with time zone", "mysql": "datetime(3)"}},
+		{"int(11)", map[string]string{"pg": "integer", "mysql": "int"}},
+		{"tinyint", map[string]string{"pg": "smallint", "mysql": "smallint", "oracle": "number(5)"}},
+		{"mediumint", map[string]string{"pg": "integer", "mssql": "int"}},
+		{"inet", map[string]string{"pg": "inet", "sqlite3": "text", "mysql": "varchar(45)", "mssql": "nvarchar(45)", "oracle": "varchar2(45)"}},
+		{"money", map[string]string{"pg": "money", "mysql": "decimal(19,4)", "mssql": "money", "oracle": "number(19,4)"}},
+		{"enum", map[string]string{"pg": "text", "mysql": "varchar(255)", "oracle": "varchar2(255)"}},
+		{"jsonb", map[string]string{"pg": "jsonb", "mysql": "json", "mssql": "nvarchar(max)"}},
+		{"Boolean", map[string]string{"pg": "boolean", "mysql": "tinyint(1)", "mssql": "bit", "oracle": "number(1)"}},
+		{"text array", map[string]string{"pg": "text[]"}},
+		{"character varying(32) array", map[string]string{"pg": "varchar(32)[]"}},
+		{"int[]", map[string]string{"pg": "integer[]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			ct := parseCanonicalType(tt.raw)
+			if !ct.IsKnown() {
+				t.Fatalf("%q is not recognized", tt.raw)
+			}
+			for d, want := range tt.want {
+				if got, ok := ct.mapTo(d); !ok || got != want {
+					t.Errorf("mapTo(%s) = %q, %v, want %q", d, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMapToUnknown(t *testing.T) {
+	for _, raw := range []string{"geometry", "inet(4)", "point", "serial array", "point array"} {
+		ct := parseCanonicalType(raw)
+		if ct.IsKnown() {
+			t.Errorf("%q is recognized as %q", raw, ct.Kind)
+		}
+		if got, ok := ct.mapTo("pg"); ok || got != raw {
+			t.Errorf("mapTo(%q) = %q, %v, want it verbatim", raw, got, ok)
+		}
+	}
+}
+
+func TestCheckTypes(t *testing.T) {
+	s := mustParse(t, "# Title: t\n\nt\n    +id [integer][NN]\n    at [time]\n    shape [geometry]\n")
+	tests := []struct {
+		name   string
+		ds     []string
+		strict bool
+		want   []string
+	}{
+		{"no DDL", nil, true, nil},
+		{"unknown type", []string{"pg"}, false, []string{`6:5: warning: unknown type "geometry" of t.shape is written verbatim`}},
+		{"strict", []string{"pg"}, true, []string{`6:5: error: unknown type "geometry"`}},
+		{"no mapping", []string{"oracle"}, false, []string{`5:5: warning: type "time" of t.at has no mapping for oracle`, `6:5: warning: unknown type`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := s.CheckTypes(tt.ds, tt.strict)
+			if len(diags) != len(tt.want) {
+				t.Fatalf("CheckTypes = %v, want %d diagnostics", diags, len(tt.want))
+			}
+			for i, d := range diags {
+				if got := d.String(); !strings.Contains(got, tt.want[i]) {
+					t.Errorf("diagnostic %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
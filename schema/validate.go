@@ -0,0 +1,232 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validate は構文としては正しいが DDL にできない定義を調べる。
+// テーブル・カラム・インデックス名の重複、存在しないテーブルやカラムへの参照、主キーのないテーブル、
+// 外部キーと参照先の型の違いを報告する。fk (...) の複数カラムのリレーションも同じように調べる。
+func (e *Schema) validate() []Diagnostic {
+	ds := []Diagnostic{}
+	tables := map[string]*Table{}
+	indexes := map[string]*Index{}
+	for ti := range e.Tables {
+		t := &e.Tables[ti]
+		if o, ok := tables[t.TitleReal]; ok {
+			ds = append(ds, newDiagnostic(SeverityError, t.Position, "table %s is already defined%s", t.TitleReal, definedAt(o.Position)))
+		} else {
+			tables[t.TitleReal] = t
+		}
+		if len(t.Columns) == 0 {
+			ds = append(ds, newDiagnostic(SeverityError, t.Position, "table %s has no columns", t.TitleReal))
+			continue
+		}
+		columns := map[string]*Column{}
+		for ci := range t.Columns {
+			c := &t.Columns[ci]
+			if o, ok := columns[c.TitleReal]; ok {
+				ds = append(ds, newDiagnostic(SeverityError, c.Position, "column %s.%s is already defined%s", t.TitleReal, c.TitleReal, definedAt(o.Position)))
+				continue
+			}
+			columns[c.TitleReal] = c
+		}
+		if len(t.PrimaryKeys) == 0 {
+			ds = append(ds, newDiagnostic(SeverityWarning, t.Position, "table %s has no primary key", t.TitleReal))
+		}
+		for ii := range t.Indexes {
+			i := &t.Indexes[ii]
+			if o, ok := indexes[i.Title]; ok {
+				ds = append(ds, newDiagnostic(SeverityError, i.Position, "index %s is already defined%s", i.Title, definedAt(o.Position)))
+			} else {
+				indexes[i.Title] = i
+			}
+			for _, c := range i.Columns {
+				if _, ok := columns[c]; !ok {
+					ds = append(ds, newDiagnostic(SeverityError, i.Position, "index %s: unknown column %s.%s", i.Title, t.TitleReal, c))
+				}
+			}
+		}
+	}
+
+	for ti := range e.Tables {
+		t := &e.Tables[ti]
+		for ci := range t.Columns {
+			c := &t.Columns[ci]
+			if !c.HasRelation() {
+				continue
+			}
+			if d, ok := validateRelation(tables, t, c); !ok {
+				ds = append(ds, d)
+			}
+		}
+		// fk (...) の制約名は fk_<table>_<columns> なので、同じカラムの組のリレーションは 2 つ書けない
+		fks := map[string]Position{}
+		for ci := range t.Columns {
+			if t.Columns[ci].HasRelation() {
+				fks[t.Columns[ci].TitleReal] = t.Columns[ci].Position
+			}
+		}
+		for ri := range t.CompositeRelations {
+			r := &t.CompositeRelations[ri]
+			key := strings.Join(r.Columns, "_")
+			if o, ok := fks[key]; ok {
+				ds = append(ds, newDiagnostic(SeverityError, r.Position, "relation of %s (%s) is already defined%s", t.TitleReal, r.GetColumns(), definedAt(o)))
+				continue
+			}
+			fks[key] = r.Position
+			ds = append(ds, validateCompositeRelation(tables, t, r)...)
+		}
+	}
+	sortDiagnostics(ds)
+	return ds
+}
+
+// validateRelation は c のリレーションの参照先を調べる。問題がなければ ok は true。
+func validateRelation(tables map[string]*Table, t *Table, c *Column) (d Diagnostic, ok bool) {
+	r := &c.Relation
+	pos := r.Position
+	if pos.Line == 0 {
+		pos = c.Position
+	}
+	rt, found := tables[r.TableNameReal]
+	if !found {
+		return newDiagnostic(SeverityError, pos, "%s.%s references unknown table %s", t.TitleReal, c.TitleReal, r.TableNameReal), false
+	}
+	var rc *Column
+	if len(r.ColumnNameReal) > 0 {
+		i, err := rt.getColumnIndex(r.ColumnNameReal)
+		if err != nil {
+			return newDiagnostic(SeverityError, pos, "%s.%s references unknown column %s.%s", t.TitleReal, c.TitleReal, rt.TitleReal, r.ColumnNameReal), false
+		}
+		rc = &rt.Columns[i]
+		if !rt.isUniqueColumn(i) {
+			return newDiagnostic(SeverityWarning, pos, "%s.%s references %s.%s, which is neither a primary key nor unique; no FOREIGN KEY constraint is written", t.TitleReal, c.TitleReal, rt.TitleReal, rc.TitleReal), false
+		}
+	} else {
+		switch len(rt.PrimaryKeys) {
+		case 0:
+			return newDiagnostic(SeverityWarning, pos, "%s.%s references %s, which has no primary key; write %s.column to reference a column", t.TitleReal, c.TitleReal, rt.TitleReal, rt.TitleReal), false
+		case 1:
+			rc = &rt.Columns[rt.PrimaryKeys[0]]
+		default:
+			return newDiagnostic(SeverityWarning, pos, "%s.%s references %s, which has a composite primary key; no FOREIGN KEY constraint is written", t.TitleReal, c.TitleReal, rt.TitleReal), false
+		}
+	}
+	if ct, rct := referenceType(c), referenceType(rc); ct != rct {
+		return newDiagnostic(SeverityWarning, c.Position, "type of %s.%s (%s) differs from %s.%s (%s)", t.TitleReal, c.TitleReal, c.Type, rt.TitleReal, rc.TitleReal, rc.Type), false
+	}
+	return Diagnostic{}, true
+}
+
+// validateCompositeRelation は fk (...) のカラムと参照先を調べる。
+func validateCompositeRelation(tables map[string]*Table, t *Table, r *CompositeRelation) []Diagnostic {
+	ds := []Diagnostic{}
+	name := "fk (" + strings.Join(r.Columns, ", ") + ")"
+	columns := []*Column{}
+	for _, c := range r.Columns {
+		i, err := t.getColumnIndex(c)
+		if err != nil {
+			ds = append(ds, newDiagnostic(SeverityError, r.Position, "%s: unknown column %s.%s", name, t.TitleReal, c))
+			continue
+		}
+		columns = append(columns, &t.Columns[i])
+	}
+	rt, found := tables[r.TableNameReal]
+	if !found {
+		return append(ds, newDiagnostic(SeverityError, r.TablePosition, "%s of %s references unknown table %s", name, t.TitleReal, r.TableNameReal))
+	}
+	refColumns := r.ReferenceColumns
+	if len(refColumns) == 0 {
+		if len(rt.PrimaryKeys) == 0 {
+			return append(ds, newDiagnostic(SeverityWarning, r.TablePosition, "%s of %s references %s, which has no primary key; write %s (columns) to reference columns", name, t.TitleReal, rt.TitleReal, rt.TitleReal))
+		}
+		refColumns = rt.GetPrimaryKeyColumnNames()
+	}
+	if len(refColumns) != len(r.Columns) {
+		return append(ds, newDiagnostic(SeverityError, r.TablePosition, "%s of %s has %d columns but references %d columns of %s", name, t.TitleReal, len(r.Columns), len(refColumns), rt.TitleReal))
+	}
+	rcs := []*Column{}
+	for _, c := range refColumns {
+		i, err := rt.getColumnIndex(c)
+		if err != nil {
+			ds = append(ds, newDiagnostic(SeverityError, r.TablePosition, "%s of %s references unknown column %s.%s", name, t.TitleReal, rt.TitleReal, c))
+			continue
+		}
+		rcs = append(rcs, &rt.Columns[i])
+	}
+	if len(ds) > 0 {
+		return ds
+	}
+	if !rt.isUniqueColumns(refColumns) {
+		ds = append(ds, newDiagnostic(SeverityWarning, r.TablePosition, "%s of %s references %s (%s), which is neither the primary key nor unique; no FOREIGN KEY constraint is written", name, t.TitleReal, rt.TitleReal, strings.Join(refColumns, ", ")))
+	}
+	for i, c := range columns {
+		if ct, rct := referenceType(c), referenceType(rcs[i]); ct != rct {
+			ds = append(ds, newDiagnostic(SeverityWarning, r.Position, "type of %s.%s (%s) differs from %s.%s (%s)", t.TitleReal, c.TitleReal, c.Type, rt.TitleReal, rcs[i].TitleReal, rcs[i].Type))
+		}
+	}
+	return ds
+}
+
+// isUniqueColumns は names のカラムの組で行が一意に決まるか（主キーか一意インデックスと同じ組か）を返す。
+func (t *Table) isUniqueColumns(names []string) bool {
+	if len(names) == 1 {
+		i, err := t.getColumnIndex(names[0])
+		return err == nil && t.isUniqueColumn(i)
+	}
+	if sameColumnSet(t.GetPrimaryKeyColumnNames(), names) {
+		return true
+	}
+	for _, i := range t.Indexes {
+		if i.IsUnique && sameColumnSet(i.Columns, names) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameColumnSet は a と b が順序を問わず同じカラムの組かを返す。
+func sameColumnSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, c := range b {
+		if !in_array(c, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// isUniqueColumn は index 番目のカラムだけで行が一意に決まるかを返す。
+func (t *Table) isUniqueColumn(index int) bool {
+	c := &t.Columns[index]
+	if c.IsUnique || (len(t.PrimaryKeys) == 1 && t.PrimaryKeys[0] == index) {
+		return true
+	}
+	for _, i := range t.Indexes {
+		if i.IsUnique && len(i.Columns) == 1 && i.Columns[0] == c.TitleReal {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceType は外部キーとその参照先の型を比べるための型名を返す。
+// serial は参照する側では元の整数型と同じに扱う。
+func referenceType(c *Column) string {
+	if !c.Canonical.IsKnown() {
+		return normalizeType(c.Type)
+	}
+	ct := c.Canonical.baseType()
+	return ct.key() + ct.args()
+}
+
+func definedAt(pos Position) string {
+	if pos.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" at line %d", pos.Line)
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"valid", testErdMSource, nil},
+		{"no primary key", "# Title: x\n\nusers\n    id [int]\n", []string{"3:1: warning: table users has no primary key"}},
+		{"type mismatch", "# Title: x\n\nusers\n    +id [bigserial][NN]\n\narticles\n    +id [int][NN]\n    user_id [int] 0..*--1 users\n", []string{
+			"8:5: warning: type of articles.user_id (int) differs from users.id (bigserial)",
+		}},
+		{"serial matches its integer type", "# Title: x\n\nusers\n    +id [bigserial][NN]\n\narticles\n    +id [int][NN]\n    user_id [bigint] 0..*--1 users\n", nil},
+		{"composite primary key", "# Title: x\n\nusers\n    +a [int][NN]\n    +b [int][NN]\n\narticles\n    +id [int][NN]\n    user_id [int] 0..*--1 users\n", []string{
+			"9:27: warning: articles.user_id references users, which has a composite primary key; no FOREIGN KEY constraint is written",
+		}},
+		{"referenced table without primary key", "# Title: x\n\nusers\n    id [int]\n\narticles\n    +id [int][NN]\n    user_id [int] 0..*--1 users\n", []string{
+			"3:1: warning: table users has no primary key",
+			"8:27: warning: articles.user_id references users, which has no primary key; write users.column to reference a column",
+		}},
+		{"referenced column", "# Title: x\n\nusers\n    +id [int][NN]\n    code [text]\n\narticles\n    +id [int][NN]\n    user_code [text] 0..*--1 users.code\n    user_id [int] 0..*--1 users.nope\n", []string{
+			"9:30: warning: articles.user_code references users.code, which is neither a primary key nor unique; no FOREIGN KEY constraint is written",
+			"10:27: error: articles.user_id references unknown column users.nope",
+		}},
+		{"duplicate index", "# Title: x\n\nusers\n    +id [int][NN]\n    index i_x (id)\n\narticles\n    +id [int][NN]\n    index i_x (id)\n", []string{
+			"9:11: error: index i_x is already defined at line 5",
+		}},
+		{"errors in line order", "# Title: x\n\nusers\n    +id [int][NN]\n    id [int]\n    x [int] 0..*--1 nope\n\nusers\n    +id [int][NN]\n", []string{
+			"5:5: error: column users.id is already defined at line 4",
+			"6:21: error: users.x references unknown table nope",
+			"8:1: error: table users is already defined at line 3",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ds := ParsePartial(strings.NewReader(tt.src))
+			got := []string{}
+			for _, d := range ds {
+				got = append(got, d.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseRejectsOnlyErrors(t *testing.T) {
+	// 警告だけなら Parse は Schema を返す
+	s, ds := Parse(strings.NewReader("# Title: x\n\nusers\n    id [int]\n"))
+	if s == nil || len(ds) != 1 || ds[0].Severity != SeverityWarning {
+		t.Errorf("Parse = %v, %v", s, ds)
+	}
+	s, ds = Parse(strings.NewReader("# Title: x\n\nusers\n    +id [int][NN] 0..*--1 nope\n"))
+	if s != nil || !Diagnostics(ds).HasError() {
+		t.Errorf("Parse = %v, %v", s, ds)
+	}
+}
@@ -0,0 +1,182 @@
+package schema
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// .erdm 形式での書き出し。import-sql などで組み立てた Schema を人が編集できるファイルにする。
+
+var erdmIdentifierPattern = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// erdmIdentifier は real_table_name / real_column_name に使えない文字を _ に置き換える。
+func erdmIdentifier(s string) string {
+	return erdmIdentifierPattern.ReplaceAllString(s, "_")
+}
+
+// erdmLogicalName は論理名を table_name / column_name の書式にする。空白や / を含む場合は "..." で囲む。
+func erdmLogicalName(s string) string {
+	s = strings.Replace(s, "\"", "", -1)
+	s = strings.Join(strings.Fields(s), " ")
+	if strings.ContainsAny(s, " /") {
+		return "\"" + s + "\""
+	}
+	return s
+}
+
+// displayWidth は全角文字を 2 桁として数えた表示幅を返す。
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		switch {
+		case r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0xA4CF, r >= 0xAC00 && r <= 0xD7A3,
+			r >= 0xF900 && r <= 0xFAFF, r >= 0xFE30 && r <= 0xFE4F, r >= 0xFF00 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6:
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
+
+func (c *Column) GetErdmHead() string {
+	s := ""
+	if c.IsPrimaryKey {
+		s += "+"
+	}
+	s += c.TitleReal
+	if len(c.Title) > 0 {
+		s += "/" + erdmLogicalName(c.Title)
+	}
+	return s
+}
+
+func (c *Column) GetErdmAttributes() string {
+	s := "[" + c.Type + "]"
+	if !c.AllowNull {
+		s += "[NN]"
+	}
+	if c.IsUnique {
+		s += "[U]"
+	}
+	if c.HasDefaultSetting() {
+		s += "[=" + c.Default + "]"
+	}
+	if c.WithoutErd {
+		s += "[-erd]"
+	}
+	if c.HasRelation() {
+		s += " " + c.Relation.GetErdmText()
+	}
+	return s
+}
+
+// GetErdmText は "0..*--1 users" のような .erdm のリレーションの書式を返す。
+func (r *TableRelation) GetErdmText() string {
+	s := r.CardinalitySource + "--" + r.CardinalityDestination + " " + r.TableNameReal
+	if len(r.ColumnNameReal) > 0 {
+		s += "." + r.ColumnNameReal
+	}
+	return s
+}
+
+// GetErdmText は "fk (tenant_id, user_id) 0..*--1 users (tenant_id, id)" のような .erdm の書式を返す。
+func (r *CompositeRelation) GetErdmText() string {
+	s := "fk (" + r.GetColumns() + ") " + r.CardinalitySource + "--" + r.CardinalityDestination + " " + r.TableNameReal
+	if len(r.ReferenceColumns) > 0 {
+		s += " (" + r.GetReferenceColumns() + ")"
+	}
+	return s
+}
+
+func lintDirectiveText(rules []string) string {
+	if lintDisabled(rules, "all") {
+		return lintDirective
+	}
+	return lintDirective + " " + strings.Join(rules, ", ")
+}
+
+// writeErdM は Schema を .erdm 形式で書き出す。カラムの [型] の位置はテーブルごとに揃える。
+// erdm fmt の書式でもあるので、読み込んだ内容は // と # のコメントも含めてそのまま書き戻せるようにする。
+func writeErdM(w io.Writer, e *Schema) error {
+	b := &strings.Builder{}
+	b.WriteString("# Title: " + e.Title + "\n")
+	for _, t := range e.Tables {
+		// テーブルの間には常に空行を 1 行入れる。コメントとテーブルの間の空行は元のとおりにする
+		b.WriteString("\n")
+		writeNotes(b, "", t.Notes, true)
+		if len(t.Notes) > 0 && t.BlankLineBefore {
+			b.WriteString("\n")
+		}
+		if len(t.LintDisable) > 0 {
+			b.WriteString("// " + lintDirectiveText(t.LintDisable) + "\n")
+		}
+		b.WriteString(t.TitleReal)
+		if len(t.Title) > 0 {
+			b.WriteString("/" + erdmLogicalName(t.Title))
+		}
+		b.WriteString("\n")
+		for _, comment := range t.Comments {
+			b.WriteString(strings.TrimRight("    # "+comment, " ") + "\n")
+		}
+		width := 0
+		for _, c := range t.Columns {
+			if w := displayWidth(c.GetErdmHead()); w > width {
+				width = w
+			}
+		}
+		for _, c := range t.Columns {
+			writeNotes(b, "    ", c.Notes, false)
+			if c.BlankLineBefore {
+				b.WriteString("\n")
+			}
+			head := c.GetErdmHead()
+			b.WriteString("    " + head + strings.Repeat(" ", width-displayWidth(head)+1) + c.GetErdmAttributes() + "\n")
+			for _, comment := range c.Comments {
+				b.WriteString(strings.TrimRight("        # "+comment, " ") + "\n")
+			}
+			if len(c.LintDisable) > 0 {
+				b.WriteString("        # " + lintDirectiveText(c.LintDisable) + "\n")
+			}
+		}
+		for _, r := range t.CompositeRelations {
+			writeNotes(b, "    ", r.Notes, false)
+			if r.BlankLineBefore {
+				b.WriteString("\n")
+			}
+			if len(r.LintDisable) > 0 {
+				b.WriteString("    // " + lintDirectiveText(r.LintDisable) + "\n")
+			}
+			b.WriteString("    " + r.GetErdmText() + "\n")
+		}
+		for _, i := range t.Indexes {
+			writeNotes(b, "    ", i.Notes, false)
+			if i.BlankLineBefore {
+				b.WriteString("\n")
+			}
+			if len(i.LintDisable) > 0 {
+				b.WriteString("    // " + lintDirectiveText(i.LintDisable) + "\n")
+			}
+			b.WriteString("    index " + i.Title + " (" + i.GetIndexColumns() + ")")
+			if i.IsUnique {
+				b.WriteString(" unique")
+			}
+			b.WriteString("\n")
+		}
+	}
+	writeNotes(b, "", e.Notes, false)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeNotes は // のコメント行を書き出す。空行の後にあったコメントの前には空行を入れる。
+// first が true なら最初のコメントの前の空行は書かない。
+func writeNotes(b *strings.Builder, indent string, notes []Comment, first bool) {
+	for i, n := range notes {
+		if n.BlankLineBefore && !(first && i == 0) {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.TrimRight(indent+"//"+n.Text, " \t") + "\n")
+	}
+}
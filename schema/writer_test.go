@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"testing"
+)
+
+func TestWriteErdMRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			"canonical layout",
+			"# Title: x\n\n\n\nusers/\"a/b\"\n  +id    [int][NN]\n  name/\"表示 名\" [text]\n  x/名前 [int][=0][-erd]\n\n\n  index   i   (id,x)\n// end\n",
+			"# Title: x\n\nusers/\"a/b\"\n    +id            [int][NN]\n    name/\"表示 名\" [text]\n    x/名前         [int][=0][-erd]\n\n    index i (id, x)\n// end\n",
+		},
+		{
+			"relations",
+			"# Title: x\n\nusers\n  +id [int][NN]\n  code [text][U]\n\narticles\n  +id [int][NN]\n  user_code [text] 0..*--0..1 users.code\n  FK(id,user_code) 0..*--1 users(id,code)\n",
+			"# Title: x\n\nusers\n    +id  [int][NN]\n    code [text][U]\n\narticles\n    +id       [int][NN]\n    user_code [text] 0..*--0..1 users.code\n    fk (id, user_code) 0..*--1 users (id, code)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Parse → writeErdM → Parse → writeErdM で同じものになる
+			got := mustRender(t, "erdm", mustParse(t, tt.src))
+			if got != tt.want {
+				t.Errorf("erdm =\n%s\nwant\n%s", got, tt.want)
+			}
+			if again := mustRender(t, "erdm", mustParse(t, got)); again != got {
+				t.Errorf("erdm is not idempotent:\n%s\nthen\n%s", got, again)
+			}
+		})
+	}
+}
+
+func TestWriteErdMKeepsSchema(t *testing.T) {
+	// 書き戻したものを読み直しても同じ Schema になる（位置は変わるので json で比べる）
+	s := mustParse(t, testErdMSource)
+	out := mustRender(t, "erdm", s)
+	again := mustParse(t, out)
+	if got, want := mustRender(t, "json", again), mustRender(t, "json", s); got != want {
+		t.Errorf("json after the round trip =\n%s\nwant\n%s", got, want)
+	}
+	if again := mustRender(t, "erdm", again); again != out {
+		t.Errorf("erdm is not idempotent:\n%s\nthen\n%s", out, again)
+	}
+}
+
+func TestErdmLogicalName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"user", "user"},
+		{"site  user", `"site user"`},
+		{"a/b", `"a/b"`},
+		{`"quoted"`, "quoted"},
+	}
+	for _, tt := range tests {
+		if got := erdmLogicalName(tt.in); got != tt.want {
+			t.Errorf("erdmLogicalName(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}